@@ -103,7 +103,10 @@ func (client *PLUGCHAINClient) RegisterModule(ms ...types.Module) {
 			panic(fmt.Sprintf("%s has register", m.Name()))
 		}
 
-		// m.RegisterCodec(client.encodingConfig.Amino)
+		// the amino names of the msgs are part of their sign bytes in SIGN_MODE_LEGACY_AMINO_JSON
+		if m, ok := m.(interface{ RegisterLegacyAminoCodec(*codec.LegacyAmino) }); ok {
+			m.RegisterLegacyAminoCodec(client.encodingConfig.Amino)
+		}
 		m.RegisterInterfaceTypes(client.encodingConfig.InterfaceRegistry)
 		client.moduleManager[m.Name()] = m
 	}
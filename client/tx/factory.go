@@ -4,6 +4,8 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/tendermint/tendermint/crypto"
+
 	sdk "plugchain-sdk-go/types"
 	"plugchain-sdk-go/types/tx/signing"
 )
@@ -30,6 +32,19 @@ type (
 		keyManager         sdk.KeyManager
 		txConfig           sdk.TxConfig
 		queryFunc          QueryWithData
+		feePayer           *FeePayer
+		feeGranter         sdk.AccAddress
+		timeoutHeight      uint64
+	}
+
+	// FeePayer is the account paying the fees of the tx, it signs the tx after the signer
+	// of the msgs unless it is the same account.
+	FeePayer struct {
+		Address       sdk.AccAddress
+		Name          string
+		Password      string
+		AccountNumber uint64
+		Sequence      uint64
 	}
 
 	// QueryWithData implements a query method from cschain.
@@ -53,6 +68,9 @@ func (f Factory) GasAdjustment() float64 { return f.gasAdjustment }
 // Fees returns the fee of the transaction.
 func (f *Factory) Fees() sdk.Coins { return f.fees }
 
+// GasPrices returns the gas prices.
+func (f *Factory) GasPrices() sdk.DecCoins { return f.gasPrices }
+
 // Sequence returns the sequence of the account.
 func (f *Factory) Sequence() uint64 { return f.sequence }
 
@@ -78,6 +96,18 @@ func (f *Factory) Password() string { return f.password }
 // Address returns the address.
 func (f *Factory) Address() string { return f.address }
 
+// FeePayer returns the fee payer.
+func (f *Factory) FeePayer() *FeePayer { return f.feePayer }
+
+// FeeGranter returns the fee granter.
+func (f *Factory) FeeGranter() sdk.AccAddress { return f.feeGranter }
+
+// TimeoutHeight returns the height after which the tx is no longer valid.
+func (f *Factory) TimeoutHeight() uint64 { return f.timeoutHeight }
+
+// SignMode returns the sign mode, the default mode of the SignModeHandler is used if it is unspecified.
+func (f *Factory) SignMode() signing.SignMode { return f.signMode }
+
 // WithChainID returns a pointer of the context with an updated ChainID.
 func (f *Factory) WithChainID(chainID string) *Factory {
 	f.chainID = chainID
@@ -102,6 +132,12 @@ func (f *Factory) WithFee(fee sdk.Coins) *Factory {
 	return f
 }
 
+// WithGasPrices returns a pointer of the context with updated gas prices.
+func (f *Factory) WithGasPrices(gasPrices sdk.DecCoins) *Factory {
+	f.gasPrices = gasPrices
+	return f
+}
+
 // WithSequence returns a pointer of the context with an updated sequence number.
 func (f *Factory) WithSequence(sequence uint64) *Factory {
 	f.sequence = sequence
@@ -156,6 +192,30 @@ func (f *Factory) WithTxConfig(txConfig sdk.TxConfig) *Factory {
 	return f
 }
 
+// WithFeePayer returns a pointer of the context with an updated fee payer.
+func (f *Factory) WithFeePayer(feePayer *FeePayer) *Factory {
+	f.feePayer = feePayer
+	return f
+}
+
+// WithFeeGranter returns a pointer of the context with an updated fee granter.
+func (f *Factory) WithFeeGranter(feeGranter sdk.AccAddress) *Factory {
+	f.feeGranter = feeGranter
+	return f
+}
+
+// WithTimeoutHeight returns a pointer of the context with an updated timeout height.
+func (f *Factory) WithTimeoutHeight(height uint64) *Factory {
+	f.timeoutHeight = height
+	return f
+}
+
+// WithSignMode returns a pointer of the context with an updated sign mode.
+func (f *Factory) WithSignMode(signMode signing.SignMode) *Factory {
+	f.signMode = signMode
+	return f
+}
+
 // WithSignModeHandler returns a pointer of the context with an signModeHandler.
 func (f *Factory) WithSignModeHandler(signModeHandler sdk.SignModeHandler) *Factory {
 	f.signModeHandler = signModeHandler
@@ -194,11 +254,28 @@ func (f *Factory) BuildAndSign(name string, msgs []sdk.Msg, json bool) ([]byte,
 	return txBytes, nil
 }
 
-func (f *Factory) BuildUnsignedTx(msgs []sdk.Msg) (sdk.TxBuilder, error) {
-	if f.chainID == "" {
-		return nil, fmt.Errorf("chain ID required but not specified")
+// BuildSimTx creates a transaction carrying an empty signature of the signers,
+// which is enough for the node to simulate it and estimate the gas.
+func (f *Factory) BuildSimTx(name string, msgs []sdk.Msg) ([]byte, error) {
+	tx, err := f.BuildUnsignedTx(msgs)
+	if err != nil {
+		return nil, err
+	}
+
+	signers, err := f.signers(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.SetSignatures(f.emptySignatures(signers)...); err != nil {
+		return nil, err
 	}
 
+	return f.txConfig.TxEncoder()(tx.GetTx())
+}
+
+// ComputeFees returns the fixed fees, or the fees derived from the gas prices and the gas limit.
+func (f *Factory) ComputeFees() (sdk.Coins, error) {
 	fees := f.fees
 
 	if !f.gasPrices.IsZero() {
@@ -216,6 +293,19 @@ func (f *Factory) BuildUnsignedTx(msgs []sdk.Msg) (sdk.TxBuilder, error) {
 			fee := gp.Amount.Mul(glDec)
 			fees[i] = sdk.NewCoin(gp.Denom, fee.Ceil().RoundInt())
 		}
+		fees = fees.Sort()
+	}
+	return fees, nil
+}
+
+func (f *Factory) BuildUnsignedTx(msgs []sdk.Msg) (sdk.TxBuilder, error) {
+	if f.chainID == "" {
+		return nil, fmt.Errorf("chain ID required but not specified")
+	}
+
+	fees, err := f.ComputeFees()
+	if err != nil {
+		return nil, err
 	}
 
 	tx := f.txConfig.NewTxBuilder()
@@ -227,74 +317,133 @@ func (f *Factory) BuildUnsignedTx(msgs []sdk.Msg) (sdk.TxBuilder, error) {
 	tx.SetMemo(f.memo)
 	tx.SetFeeAmount(fees)
 	tx.SetGasLimit(f.gas)
-	//f.txBuilder.SetTimeoutHeight(f.TimeoutHeight())
+	tx.SetTimeoutHeight(f.timeoutHeight)
+	if f.feePayer != nil {
+		tx.SetFeePayer(f.feePayer.Address)
+	}
+	if !f.feeGranter.Empty() {
+		tx.SetFeeGranter(f.feeGranter)
+	}
 
 	return tx, nil
 }
 
 // Sign signs a transaction given a name, passphrase, and a single message to
-// signed. An error is returned if signing fails.
+// signed. The fee payer signs the transaction too if it is another account.
+// An error is returned if signing fails.
 func (f *Factory) Sign(name string, txBuilder sdk.TxBuilder) error {
-	signMode := f.signMode
-	if signMode == signing.SignMode_SIGN_MODE_UNSPECIFIED {
-		// use the SignModeHandler's default mode if unspecified
-		signMode = f.txConfig.SignModeHandler().DefaultMode()
-	}
-	signerData := sdk.SignerData{
-		ChainID:       f.chainID,
-		AccountNumber: f.accountNumber,
-		Sequence:      f.sequence,
-	}
-
-	pubkey, _, err := f.keyManager.Find(name, f.password)
+	signers, err := f.signers(name)
 	if err != nil {
 		return err
 	}
 
 	// For SIGN_MODE_DIRECT, calling SetSignatures calls setSignerInfos on
 	// Factory under the hood, and SignerInfos is needed to generated the
-	// sign bytes. This is the reason for setting SetSignatures here, with a
-	// nil signature.
+	// sign bytes. This is the reason for setting SetSignatures here, with
+	// nil signatures of every signer, so that all of them sign the same SignerInfos.
 	//
 	// Note: this line is not needed for SIGN_MODE_LEGACY_AMINO, but putting it
 	// also doesn't affect its generated sign bytes, so for code's simplicity
 	// sake, we put it here.
-	sigData := signing.SingleSignatureData{
-		SignMode:  signMode,
-		Signature: nil,
-	}
-	sig := signing.SignatureV2{
-		PubKey:   pubkey,
-		Data:     &sigData,
-		Sequence: f.Sequence(),
-	}
-	if err := txBuilder.SetSignatures(sig); err != nil {
+	sigs := f.emptySignatures(signers)
+	if err := txBuilder.SetSignatures(sigs...); err != nil {
 		return err
 	}
 
-	// Generate the bytes to be signed.
-	signBytes, err := f.signModeHandler.GetSignBytes(signMode, signerData, txBuilder.GetTx())
-	if err != nil {
-		return err
+	for i, signer := range signers {
+		// Generate the bytes to be signed.
+		signBytes, err := f.signModeHandler.GetSignBytes(f.getSignMode(), signer.data, txBuilder.GetTx())
+		if err != nil {
+			return err
+		}
+
+		// Sign those bytes
+		sigBytes, _, err := f.keyManager.Sign(signer.name, signer.password, signBytes)
+		if err != nil {
+			return err
+		}
+
+		// Construct the SignatureV2 struct
+		sigs[i] = signing.SignatureV2{
+			PubKey: signer.pubKey,
+			Data: &signing.SingleSignatureData{
+				SignMode:  f.getSignMode(),
+				Signature: sigBytes,
+			},
+			Sequence: signer.data.Sequence,
+		}
 	}
 
-	// Sign those bytes
-	sigBytes, _, err := f.keyManager.Sign(name, f.password, signBytes)
+	// And here the tx is populated with the signatures
+	return txBuilder.SetSignatures(sigs...)
+}
+
+// signer is a key signing the tx, in the order of the signers of the tx
+type signer struct {
+	name     string
+	password string
+	pubKey   crypto.PubKey
+	data     sdk.SignerData
+}
+
+// signers returns the key name, which signs the msgs, followed by the fee payer if it is another account
+func (f *Factory) signers(name string) ([]signer, error) {
+	pubKey, _, err := f.keyManager.Find(name, f.password)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	// Construct the SignatureV2 struct
-	sigData = signing.SingleSignatureData{
-		SignMode:  signMode,
-		Signature: sigBytes,
+	signers := []signer{{
+		name:     name,
+		password: f.password,
+		pubKey:   pubKey,
+		data: sdk.SignerData{
+			ChainID:       f.chainID,
+			AccountNumber: f.accountNumber,
+			Sequence:      f.sequence,
+		},
+	}}
+
+	payer := f.feePayer
+	if payer == nil || payer.Address.Equals(sdk.AccAddress(pubKey.Address())) {
+		return signers, nil
 	}
-	sig = signing.SignatureV2{
-		PubKey:   pubkey,
-		Data:     &sigData,
-		Sequence: f.Sequence(),
+
+	payerKey, _, err := f.keyManager.Find(payer.Name, payer.Password)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(signers, signer{
+		name:     payer.Name,
+		password: payer.Password,
+		pubKey:   payerKey,
+		data: sdk.SignerData{
+			ChainID:       f.chainID,
+			AccountNumber: payer.AccountNumber,
+			Sequence:      payer.Sequence,
+		},
+	}), nil
+}
+
+func (f *Factory) emptySignatures(signers []signer) []signing.SignatureV2 {
+	sigs := make([]signing.SignatureV2, len(signers))
+	for i, signer := range signers {
+		sigs[i] = signing.SignatureV2{
+			PubKey: signer.pubKey,
+			Data: &signing.SingleSignatureData{
+				SignMode: f.getSignMode(),
+			},
+			Sequence: signer.data.Sequence,
+		}
 	}
+	return sigs
+}
 
-	// And here the tx is populated with the signature
-	return txBuilder.SetSignatures(sig)
+// getSignMode returns the sign mode, or the SignModeHandler's default mode if it is unspecified
+func (f *Factory) getSignMode() signing.SignMode {
+	if f.signMode == signing.SignMode_SIGN_MODE_UNSPECIFIED {
+		return f.txConfig.SignModeHandler().DefaultMode()
+	}
+	return f.signMode
 }
@@ -0,0 +1,498 @@
+package plugchain_sdk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/p2p"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	"google.golang.org/grpc"
+
+	clienttx "plugchain-sdk-go/client/tx"
+	cdctypes "plugchain-sdk-go/codec/types"
+	"plugchain-sdk-go/modules/auth"
+	"plugchain-sdk-go/modules/bank"
+	"plugchain-sdk-go/modules/coinswap"
+	"plugchain-sdk-go/modules/gov"
+	"plugchain-sdk-go/modules/nft"
+	"plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/store"
+	txtypes "plugchain-sdk-go/types/tx"
+	"plugchain-sdk-go/types/tx/signing"
+	"plugchain-sdk-go/utils/rpctest"
+)
+
+// newOfflineClient returns a client keeping its keys in memory, its nodes are unreachable
+func newOfflineClient(t *testing.T) PLUGCHAINClient {
+	cfg, err := types.NewClientConfig("tcp://127.0.0.1:1", "127.0.0.1:1", "chaintest-1",
+		types.KeyDAOOption(store.NewMemory(nil)),
+		types.TimeoutOption(1),
+	)
+	require.NoError(t, err)
+
+	client := NewPLUGCHAINClient(cfg)
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func TestOfflineSigning(t *testing.T) {
+	// nothing listens on the node addresses, the offline workflow must not need them
+	client := newOfflineClient(t)
+
+	from, _, e := client.Key.Add("cold", "password")
+	require.Nil(t, e)
+
+	msg := &bank.MsgSend{
+		FromAddress: from,
+		ToAddress:   from,
+		Amount:      types.NewCoins(types.NewCoin("plug", types.NewInt(1))),
+	}
+	unsignedTx, e := client.BuildUnsignedTx(from, []types.Msg{msg}, types.BaseTx{AccountNumber: 3, Sequence: 5})
+	require.Nil(t, e)
+
+	var unsigned types.UnsignedTx
+	require.NoError(t, json.Unmarshal(unsignedTx, &unsigned))
+	require.Equal(t, "chaintest-1", unsigned.ChainID)
+	require.Equal(t, uint64(3), unsigned.AccountNumber)
+	require.Equal(t, uint64(5), unsigned.Sequence)
+
+	signedTx, e := client.SignTx(unsignedTx, "cold", "password")
+	require.Nil(t, e)
+
+	decoded, err := client.EncodingConfig().TxConfig.TxJSONDecoder()(signedTx)
+	require.NoError(t, err)
+	sigs, err := decoded.(interface {
+		GetSignaturesV2() ([]signing.SignatureV2, error)
+	}).GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	require.Equal(t, uint64(5), sigs[0].Sequence)
+
+	_, err = client.EncodingConfig().TxConfig.TxEncoder()(decoded)
+	require.NoError(t, err)
+}
+
+func TestMultisig(t *testing.T) {
+	client := newOfflineClient(t)
+
+	var pubKeys []types.TmPubKey
+	for _, name := range []string{"a", "b", "c"} {
+		_, _, e := client.Key.Add(name, "password")
+		require.Nil(t, e)
+		pubKey, _, err := client.Find(name, "password")
+		require.NoError(t, err)
+		pubKeys = append(pubKeys, pubKey)
+	}
+
+	_, _, e := client.NewMultisigPubKey(4, pubKeys)
+	require.NotNil(t, e)
+
+	multisigPubKey, multisigAddr, e := client.NewMultisigPubKey(2, pubKeys)
+	require.Nil(t, e)
+
+	msg := &bank.MsgSend{
+		FromAddress: multisigAddr,
+		ToAddress:   multisigAddr,
+		Amount:      types.NewCoins(types.NewCoin("plug", types.NewInt(1))),
+	}
+	unsignedTx, e := client.BuildUnsignedTx(multisigAddr, []types.Msg{msg}, types.BaseTx{AccountNumber: 3, Sequence: 5})
+	require.Nil(t, e)
+
+	sigA, e := client.SignMultisigTx(unsignedTx, "a", "password")
+	require.Nil(t, e)
+	sigC, e := client.SignMultisigTx(unsignedTx, "c", "password")
+	require.Nil(t, e)
+
+	// the threshold is not reached with one signature
+	_, e = client.MultisignTx(unsignedTx, multisigPubKey, sigC)
+	require.NotNil(t, e)
+
+	signedTx, e := client.MultisignTx(unsignedTx, multisigPubKey, sigC, sigA)
+	require.Nil(t, e)
+
+	decoded, err := client.EncodingConfig().TxConfig.TxJSONDecoder()(signedTx)
+	require.NoError(t, err)
+	sigs, err := decoded.(interface {
+		GetSignaturesV2() ([]signing.SignatureV2, error)
+	}).GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+	require.True(t, sigs[0].PubKey.Equals(multisigPubKey))
+
+	multisigData, ok := sigs[0].Data.(*signing.MultiSignatureData)
+	require.True(t, ok)
+	require.True(t, multisigData.BitArray.GetIndex(0))
+	require.False(t, multisigData.BitArray.GetIndex(1))
+	require.True(t, multisigData.BitArray.GetIndex(2))
+	require.Len(t, multisigData.Signatures, 2)
+
+	// the signatures are checked against the amino JSON sign doc of the tx
+	feeTx := decoded.(types.FeeTx)
+	signBytes := types.StdSignBytes("chaintest-1", 3, 5, 0,
+		types.NewStdFee(feeTx.GetGas(), feeTx.GetFee()...), []types.Msg{msg}, "")
+	require.True(t, pubKeys[0].VerifySignature(signBytes, multisigData.Signatures[0].(*signing.SingleSignatureData).Signature))
+
+	_, err = client.EncodingConfig().TxConfig.TxEncoder()(decoded)
+	require.NoError(t, err)
+}
+
+func TestLegacyAminoJSONSignMode(t *testing.T) {
+	client := newOfflineClient(t)
+
+	from, _, e := client.Key.Add("amino", "password")
+	require.Nil(t, e)
+
+	msg := &bank.MsgSend{
+		FromAddress: from,
+		ToAddress:   from,
+		Amount:      types.NewCoins(types.NewCoin("plug", types.NewInt(1))),
+	}
+	require.Equal(t,
+		`{"type":"cosmos-sdk/MsgSend","value":{"amount":[{"amount":"1","denom":"plug"}],"from_address":"`+from+`","to_address":"`+from+`"}}`,
+		string(msg.GetSignBytes()))
+
+	unsignedTx, e := client.BuildUnsignedTx(from, []types.Msg{msg}, types.BaseTx{
+		AccountNumber: 3,
+		Sequence:      5,
+		Gas:           100000,
+		Memo:          "memo",
+		SignMode:      signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+	})
+	require.Nil(t, e)
+
+	signedTx, e := client.SignTx(unsignedTx, "amino", "password")
+	require.Nil(t, e)
+
+	decoded, err := client.EncodingConfig().TxConfig.TxJSONDecoder()(signedTx)
+	require.NoError(t, err)
+	sigs, err := decoded.(interface {
+		GetSignaturesV2() ([]signing.SignatureV2, error)
+	}).GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 1)
+
+	sigData, ok := sigs[0].Data.(*signing.SingleSignatureData)
+	require.True(t, ok)
+	require.Equal(t, signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, sigData.SignMode)
+
+	feeTx := decoded.(types.FeeTx)
+	signBytes := types.StdSignBytes("chaintest-1", 3, 5, 0,
+		types.NewStdFee(feeTx.GetGas(), feeTx.GetFee()...), []types.Msg{msg}, "memo")
+	require.True(t, sigs[0].PubKey.VerifySignature(signBytes, sigData.Signature))
+}
+
+func TestFeePayer(t *testing.T) {
+	client := newOfflineClient(t)
+
+	from, _, e := client.Key.Add("user", "password")
+	require.Nil(t, e)
+	payer, _, e := client.Key.Add("sponsor", "password")
+	require.Nil(t, e)
+	payerAddr, e := types.AccAddressFromBech32(payer)
+	require.Nil(t, e)
+	granter := types.AccAddress([]byte("granter_____________"))
+
+	txConfig := client.EncodingConfig().TxConfig
+	factory := clienttx.NewFactory().
+		WithChainID("chaintest-1").
+		WithKeyManager(client.BaseClient).
+		WithAccountNumber(3).
+		WithSequence(5).
+		WithPassword("password").
+		WithGas(100000).
+		WithTimeoutHeight(1000).
+		WithFeeGranter(granter).
+		WithFeePayer(&clienttx.FeePayer{
+			Address:       payerAddr,
+			Name:          "sponsor",
+			Password:      "password",
+			AccountNumber: 8,
+			Sequence:      13,
+		}).
+		WithSignModeHandler(txtypes.MakeSignModeHandler(txtypes.DefaultSignModes)).
+		WithTxConfig(txConfig)
+
+	msg := &bank.MsgSend{
+		FromAddress: from,
+		ToAddress:   from,
+		Amount:      types.NewCoins(types.NewCoin("plug", types.NewInt(1))),
+	}
+	txBytes, err := factory.BuildAndSign("user", []types.Msg{msg}, false)
+	require.NoError(t, err)
+
+	decoded, err := txConfig.TxDecoder()(txBytes)
+	require.NoError(t, err)
+
+	feeTx := decoded.(types.FeeTx)
+	require.Equal(t, payerAddr, feeTx.FeePayer())
+	require.Equal(t, granter, feeTx.FeeGranter())
+	require.Equal(t, uint64(1000), decoded.(interface{ GetTimeoutHeight() uint64 }).GetTimeoutHeight())
+
+	// the fee payer signs after the signer of the msgs, with its own account
+	signers := decoded.(interface{ GetSigners() []types.AccAddress }).GetSigners()
+	require.Equal(t, []types.AccAddress{msg.GetSigners()[0], payerAddr}, signers)
+
+	sigs, err := decoded.(interface {
+		GetSignaturesV2() ([]signing.SignatureV2, error)
+	}).GetSignaturesV2()
+	require.NoError(t, err)
+	require.Len(t, sigs, 2)
+
+	for i, data := range []types.SignerData{
+		{ChainID: "chaintest-1", AccountNumber: 3, Sequence: 5},
+		{ChainID: "chaintest-1", AccountNumber: 8, Sequence: 13},
+	} {
+		require.Equal(t, data.Sequence, sigs[i].Sequence)
+		signBytes, err := txConfig.SignModeHandler().GetSignBytes(signing.SignMode_SIGN_MODE_DIRECT, data, decoded)
+		require.NoError(t, err)
+		sig := sigs[i].Data.(*signing.SingleSignatureData).Signature
+		require.True(t, sigs[i].PubKey.VerifySignature(signBytes, sig))
+	}
+}
+
+// stubAuthServer answers the account queries with the accounts set by the test
+type stubAuthServer struct {
+	auth.UnimplementedQueryServer
+	mtx      sync.Mutex
+	accounts map[string]*auth.BaseAccount
+	queries  int
+}
+
+func (s *stubAuthServer) setAccount(account *auth.BaseAccount) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.accounts[account.Address] = account
+}
+
+func (s *stubAuthServer) Account(ctx context.Context, req *auth.QueryAccountRequest) (*auth.QueryAccountResponse, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.queries++
+	account, ok := s.accounts[req.Address]
+	if !ok {
+		return nil, fmt.Errorf("account %s not found", req.Address)
+	}
+	any, err := cdctypes.NewAnyWithValue(account)
+	if err != nil {
+		return nil, err
+	}
+	return &auth.QueryAccountResponse{Account: any}, nil
+}
+
+type stubBankServer struct {
+	bank.UnimplementedQueryServer
+}
+
+func (s *stubBankServer) AllBalances(ctx context.Context, req *bank.QueryAllBalancesRequest) (*bank.QueryAllBalancesResponse, error) {
+	return &bank.QueryAllBalancesResponse{}, nil
+}
+
+func TestFeePayerBaseTx(t *testing.T) {
+	// the first tx is rejected because of the sequence of the fee payer
+	txs := make(chan []byte, 10)
+	var broadcasts int32
+	node := rpctest.NewNode(t, map[string]func(rpctypes.RPCRequest) rpctypes.RPCResponse{
+		"status": rpctest.Result(&ctypes.ResultStatus{NodeInfo: p2p.DefaultNodeInfo{Network: "chaintest-1"}}),
+		"broadcast_tx_sync": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			var params struct {
+				Tx []byte `json:"tx"`
+			}
+			_ = json.Unmarshal(req.Params, &params)
+			txs <- params.Tx
+			result := &ctypes.ResultBroadcastTx{}
+			if atomic.AddInt32(&broadcasts, 1) == 1 {
+				result.Code = 32
+				result.Codespace = types.RootCodespace
+				result.Log = "account sequence mismatch, expected 11, got 9: incorrect account sequence"
+			}
+			return rpctypes.NewRPCSuccessResponse(req.ID, result)
+		},
+	})
+
+	authServer := &stubAuthServer{accounts: make(map[string]*auth.BaseAccount)}
+	server := grpc.NewServer()
+	auth.RegisterQueryServer(server, authServer)
+	bank.RegisterQueryServer(server, &stubBankServer{})
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = server.Serve(lis) }()
+	defer server.Stop()
+
+	cfg, err := types.NewClientConfig(node.URL, lis.Addr().String(), "chaintest-1",
+		types.KeyDAOOption(store.NewMemory(nil)),
+		types.TimeoutOption(1),
+		types.ModeOption(types.Sync),
+	)
+	require.NoError(t, err)
+
+	client := NewPLUGCHAINClient(cfg)
+	defer client.Close()
+	client.SaveTokens(types.Token{Symbol: "plug", MinUnit: "plug"})
+
+	from, _, e := client.Key.Add("user", "password")
+	require.Nil(t, e)
+	payer, _, e := client.Key.Add("sponsor", "password")
+	require.Nil(t, e)
+	payerAddr, err := types.AccAddressFromBech32(payer)
+	require.NoError(t, err)
+	granter := types.AccAddress([]byte("granter_____________"))
+	authServer.setAccount(&auth.BaseAccount{Address: from, AccountNumber: 3, Sequence: 5})
+	authServer.setAccount(&auth.BaseAccount{Address: payer, AccountNumber: 8, Sequence: 9})
+
+	msg := &bank.MsgSend{
+		FromAddress: from,
+		ToAddress:   from,
+		Amount:      types.NewCoins(types.NewCoin("plug", types.NewInt(1))),
+	}
+	baseTx := types.BaseTx{
+		From:             "user",
+		Password:         "password",
+		FeePayer:         "sponsor",
+		FeePayerPassword: "password",
+		FeeGranter:       granter.String(),
+		TimeoutHeight:    1000,
+	}
+
+	// the tx is signed again with the sequence of the fee payer expected by the node
+	_, e = client.BuildAndSend([]types.Msg{msg}, baseTx)
+	require.Nil(t, e)
+	_, e = client.BuildAndSend([]types.Msg{msg}, baseTx)
+	require.Nil(t, e)
+	close(txs)
+
+	txConfig := client.EncodingConfig().TxConfig
+	var sequences [][]uint64
+	for txBytes := range txs {
+		decoded, err := txConfig.TxDecoder()(txBytes)
+		require.NoError(t, err)
+
+		feeTx := decoded.(types.FeeTx)
+		require.Equal(t, payerAddr, feeTx.FeePayer())
+		require.Equal(t, granter, feeTx.FeeGranter())
+		require.Equal(t, uint64(1000), decoded.(interface{ GetTimeoutHeight() uint64 }).GetTimeoutHeight())
+		signers := decoded.(interface{ GetSigners() []types.AccAddress }).GetSigners()
+		require.Equal(t, []types.AccAddress{msg.GetSigners()[0], payerAddr}, signers)
+
+		sigs, err := decoded.(interface {
+			GetSignaturesV2() ([]signing.SignatureV2, error)
+		}).GetSignaturesV2()
+		require.NoError(t, err)
+		sequences = append(sequences, []uint64{sigs[0].Sequence, sigs[1].Sequence})
+	}
+	require.Equal(t, [][]uint64{{5, 9}, {5, 11}, {6, 12}}, sequences)
+
+	// the sequences are counted locally after the first load
+	authServer.mtx.Lock()
+	defer authServer.mtx.Unlock()
+	require.Equal(t, 2, authServer.queries)
+}
+
+func TestMultiMsgTx(t *testing.T) {
+	client := newOfflineClient(t)
+
+	owner, _, e := client.Key.Add("owner", "password")
+	require.Nil(t, e)
+
+	// the msgs of several modules are sent in one tx
+	tx := client.NewTx().
+		Add(client.Token.MintTokenMsg(owner, "plug", 100, "")).
+		Add(client.Nft.TransferNFTMsg(owner, nft.TransferNFTRequest{ID: "nft1", ClassID: "class1", Recipient: owner})).
+		Add(client.Swap.WithdrawWithinMsg(owner, coinswap.WithdrawWithinRequest{PoolId: 1, PoolCoin: types.NewCoin("pool1", types.NewInt(10))}))
+	require.NoError(t, tx.Err())
+	require.Len(t, tx.Msgs(), 3)
+
+	unsignedTx, e := client.BuildUnsignedTx(owner, tx.Msgs(), types.BaseTx{AccountNumber: 3, Sequence: 5})
+	require.Nil(t, e)
+	var unsigned types.UnsignedTx
+	require.NoError(t, json.Unmarshal(unsignedTx, &unsigned))
+
+	// the first invalid msg fails the tx before anything is sent
+	tx = client.NewTx().
+		Add(client.Nft.TransferNFTMsg(owner, nft.TransferNFTRequest{ID: "nft1", ClassID: "class1", Recipient: "invalid"})).
+		Add(client.Token.MintTokenMsg(owner, "plug", 100, ""))
+	require.Error(t, tx.Err())
+	require.Len(t, tx.Msgs(), 0)
+	_, e = tx.Send(types.BaseTx{From: "owner", Password: "password"})
+	require.Error(t, e)
+
+	_, e = client.NewTx().Send(types.BaseTx{From: "owner", Password: "password"})
+	require.Error(t, e)
+
+	// the account of the msgs and the vote option are validated by the builders
+	_, e = client.Nft.BurnNFTMsg("invalid", nft.BurnNFTRequest{ID: "nft1", ClassID: "class1"})
+	require.Error(t, e)
+	_, err := client.Swap.WithdrawWithinMsg("invalid", coinswap.WithdrawWithinRequest{PoolId: 1})
+	require.Error(t, err)
+	_, e = client.Gov.VoteMsg(owner, gov.VoteRequest{ProposalId: 1, Option: "VoteOptionYes"})
+	require.Error(t, e)
+	_, e = client.Gov.VoteMsg(owner, gov.VoteRequest{ProposalId: 1, Option: gov.OptionYes.String()})
+	require.Nil(t, e)
+}
+
+// batchClient sends the batches of msgs nowhere, maxReceipts receipts fit in a tx
+type batchClient struct {
+	types.BaseClient
+	maxReceipts int
+	sent        types.Msgs
+}
+
+func (c *batchClient) QueryAddress(name, password string) (types.AccAddress, types.Error) {
+	return types.AccAddress([]byte("sender______________")), nil
+}
+
+func (c *batchClient) ToMinCoinContext(ctx context.Context, coins ...types.DecCoin) (types.Coins, types.Error) {
+	var minCoins types.Coins
+	for _, coin := range coins {
+		minCoins = append(minCoins, types.NewCoin(coin.Denom, coin.Amount.TruncateInt()))
+	}
+	return minCoins, nil
+}
+
+func (c *batchClient) BatchSize(ctx context.Context, msgs []types.Msg) int {
+	if len(msgs) < c.maxReceipts {
+		return len(msgs)
+	}
+	return c.maxReceipts
+}
+
+func (c *batchClient) SendBatchContext(ctx context.Context, msgs types.Msgs, baseTx types.BaseTx) ([]types.ResultTx, types.Error) {
+	c.sent = msgs
+	return make([]types.ResultTx, len(msgs)), nil
+}
+
+func TestMultiSendBatches(t *testing.T) {
+	var request bank.MultiSendRequest
+	for i := 0; i < 7; i++ {
+		request.Receipts = append(request.Receipts, bank.Receipt{
+			Address: types.AccAddress([]byte(fmt.Sprintf("receipt_%012d", i))).String(),
+			Amount:  types.NewDecCoins(types.NewDecCoin("plug", types.NewInt(int64(i+1)))),
+		})
+	}
+
+	// the receipts are split into msgs by the size of a tx
+	c := &batchClient{maxReceipts: 3}
+	res, e := bank.NewClient(c, nil).MultiSend(request, types.BaseTx{From: "sender"})
+	require.Nil(t, e)
+	require.Len(t, res, 3)
+	require.Len(t, c.sent, 3)
+	for i, outputs := range []int{3, 3, 1} {
+		msg := c.sent[i].(*bank.MsgMultiSend)
+		require.Len(t, msg.Inputs, outputs)
+		require.Len(t, msg.Outputs, outputs)
+	}
+	require.Equal(t, request.Receipts[6].Address, c.sent[2].(*bank.MsgMultiSend).Outputs[0].Address)
+
+	// a few receipts are sent in one msg
+	c = &batchClient{maxReceipts: 100}
+	res, e = bank.NewClient(c, nil).MultiSend(request, types.BaseTx{From: "sender"})
+	require.Nil(t, e)
+	require.Len(t, res, 1)
+	require.Len(t, c.sent[0].(*bank.MsgMultiSend).Outputs, 7)
+}
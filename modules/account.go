@@ -14,7 +14,6 @@ import (
 	"plugchain-sdk-go/utils/cache"
 )
 
-// Must be used with locker, otherwise there are thread safety issues
 type accountQuery struct {
 	sdk.Queries
 	sdk.GRPCClient
@@ -25,29 +24,12 @@ type accountQuery struct {
 	expiration time.Duration
 }
 
-func (a accountQuery) QueryAndRefreshAccount(address string) (sdk.BaseAccount, sdk.Error) {
-	account, err := a.Get(a.prefixKey(address))
-	if err != nil {
-		return a.refresh(address)
-	}
-
-	acc := account.(accountInfo)
-	baseAcc := sdk.BaseAccount{
-		Address:       address,
-		AccountNumber: acc.N,
-		Sequence:      acc.S + 1,
-	}
-	a.saveAccount(baseAcc)
-
-	a.Debug("query account from cache", "address", address)
-	return baseAcc, nil
+func (a accountQuery) QueryAccount(address string) (sdk.BaseAccount, sdk.Error) {
+	return a.QueryAccountContext(context.Background(), address)
 }
 
-func (a accountQuery) QueryAccount(address string) (sdk.BaseAccount, sdk.Error) {
+func (a accountQuery) QueryAccountContext(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
 	conn, err := a.GenConn()
-	defer func() {
-		_ = conn.Close()
-	}()
 	if err != nil {
 		return sdk.BaseAccount{}, sdk.Wrap(err)
 	}
@@ -56,7 +38,7 @@ func (a accountQuery) QueryAccount(address string) (sdk.BaseAccount, sdk.Error)
 		Address: address,
 	}
 
-	response, err := auth.NewQueryClient(conn).Account(context.Background(), request)
+	response, err := auth.NewQueryClient(conn).Account(ctx, request)
 	if err != nil {
 		return sdk.BaseAccount{}, sdk.Wrap(err)
 	}
@@ -72,7 +54,7 @@ func (a accountQuery) QueryAccount(address string) (sdk.BaseAccount, sdk.Error)
 		Address:    address,
 		Pagination: nil,
 	}
-	balances, err := bank.NewQueryClient(conn).AllBalances(context.Background(), breq)
+	balances, err := bank.NewQueryClient(conn).AllBalances(ctx, breq)
 	if err != nil {
 		return sdk.BaseAccount{}, sdk.Wrap(err)
 	}
@@ -81,6 +63,12 @@ func (a accountQuery) QueryAccount(address string) (sdk.BaseAccount, sdk.Error)
 	return account, nil
 }
 
+// queryLatestAccount queries the account at the latest height whatever the height of the queries,
+// the txs are signed with the current sequence
+func (a accountQuery) queryLatestAccount(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
+	return a.QueryAccountContext(sdk.ContextWithHeight(ctx, 0), address)
+}
+
 func (a accountQuery) QueryAddress(name, password string) (sdk.AccAddress, sdk.Error) {
 	addr, err := a.Get(a.prefixKey(name))
 	if err == nil {
@@ -106,39 +94,6 @@ func (a accountQuery) QueryAddress(name, password string) (sdk.AccAddress, sdk.E
 	return address, nil
 }
 
-func (a accountQuery) removeCache(address string) bool {
-	return a.Remove(a.prefixKey(address))
-}
-
-func (a accountQuery) refresh(address string) (sdk.BaseAccount, sdk.Error) {
-	account, err := a.QueryAccount(address)
-	if err != nil {
-		a.Error("update cache failed", "address", address, "errMsg", err.Error())
-		return sdk.BaseAccount{}, sdk.Wrap(err)
-	}
-
-	a.saveAccount(account)
-	return account, nil
-}
-
-func (a accountQuery) saveAccount(account sdk.BaseAccount) {
-	address := account.Address
-	info := accountInfo{
-		N: account.AccountNumber,
-		S: account.Sequence,
-	}
-	if err := a.SetWithExpire(a.prefixKey(address), info, a.expiration); err != nil {
-		a.Debug("cache user failed", "address", account.Address)
-		return
-	}
-	a.Debug("cache account", "address", address, "expiration", a.expiration.String())
-}
-
 func (a accountQuery) prefixKey(address string) string {
 	return fmt.Sprintf("account:%s", address)
 }
-
-type accountInfo struct {
-	N uint64 `json:"n"`
-	S uint64 `json:"s"`
-}
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"strings"
 
-	"plugchain-sdk-go/utils"
-
 	"plugchain-sdk-go/codec"
 	"plugchain-sdk-go/codec/types"
 	sdk "plugchain-sdk-go/types"
@@ -32,9 +30,17 @@ func (b bankClient) RegisterInterfaceTypes(registry types.InterfaceRegistry) {
 	RegisterInterfaces(registry)
 }
 
+func (b bankClient) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	RegisterLegacyAminoCodec(cdc)
+}
+
 // QueryAccount return account information specified address
 func (b bankClient) QueryAccount(address string) (sdk.BaseAccount, sdk.Error) {
-	account, err := b.BaseClient.QueryAccount(address)
+	return b.QueryAccountContext(context.Background(), address)
+}
+
+func (b bankClient) QueryAccountContext(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
+	account, err := b.BaseClient.QueryAccountContext(ctx, address)
 	if err != nil {
 		return sdk.BaseAccount{}, sdk.Wrap(err)
 	}
@@ -44,14 +50,17 @@ func (b bankClient) QueryAccount(address string) (sdk.BaseAccount, sdk.Error) {
 
 //  TotalSupply queries the total supply of all coins.
 func (b bankClient) TotalSupply() (sdk.Coins, sdk.Error) {
+	return b.TotalSupplyContext(context.Background())
+}
+
+func (b bankClient) TotalSupplyContext(ctx context.Context) (sdk.Coins, sdk.Error) {
 	conn, err := b.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return nil, sdk.Wrap(err)
 	}
 
 	resp, err := NewQueryClient(conn).TotalSupply(
-		context.Background(),
+		ctx,
 		&QueryTotalSupplyRequest{},
 	)
 	if err != nil {
@@ -62,59 +71,92 @@ func (b bankClient) TotalSupply() (sdk.Coins, sdk.Error) {
 
 // Send is responsible for transferring tokens from `From` to `to` account
 func (b bankClient) Send(to string, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return b.SendContext(context.Background(), to, amount, baseTx)
+}
+
+func (b bankClient) SendContext(ctx context.Context, to string, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	sender, err := b.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrapf("%s not found", baseTx.From)
 	}
 
-	amt, err := b.ToMinCoin(amount...)
+	msg, err := b.SendMsgContext(ctx, sender.String(), to, amount)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return b.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// SendMsg returns the msg of Send from the account `from`, to be sent with the msgs of other modules
+func (b bankClient) SendMsg(from, to string, amount sdk.DecCoins) (sdk.Msg, sdk.Error) {
+	return b.SendMsgContext(context.Background(), from, to, amount)
+}
+
+func (b bankClient) SendMsgContext(ctx context.Context, from, to string, amount sdk.DecCoins) (sdk.Msg, sdk.Error) {
+	sender, err := sdk.AccAddressFromBech32(from)
+	if err != nil {
+		return nil, sdk.Wrapf(fmt.Sprintf("%s invalid address", from))
+	}
+
+	amt, err := b.ToMinCoinContext(ctx, amount...)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return nil, sdk.Wrap(err)
 	}
 
 	outAddr, err := sdk.AccAddressFromBech32(to)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrapf(fmt.Sprintf("%s invalid address", to))
+		return nil, sdk.Wrapf(fmt.Sprintf("%s invalid address", to))
 	}
 
-	msg := NewMsgSend(sender, outAddr, amt)
-	return b.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	return NewMsgSend(sender, outAddr, amt), nil
 }
 
 func (b bankClient) SendWitchSpecAccountInfo(to string, sequence, accountNumber uint64, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return b.SendWitchSpecAccountInfoContext(context.Background(), to, sequence, accountNumber, amount, baseTx)
+}
+
+func (b bankClient) SendWitchSpecAccountInfoContext(ctx context.Context, to string, sequence, accountNumber uint64, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	sender, err := b.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrapf("%s not found", baseTx.From)
 	}
 
-	amt, err := b.ToMinCoin(amount...)
+	msg, err := b.SendMsgContext(ctx, sender.String(), to, amount)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return sdk.ResultTx{}, err
 	}
-
-	outAddr, err := sdk.AccAddressFromBech32(to)
-	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrapf(fmt.Sprintf("%s invalid address", to))
-	}
-
-	msg := NewMsgSend(sender, outAddr, amt)
-	return b.BuildAndSendWithAccount(sender.String(), accountNumber, sequence, []sdk.Msg{msg}, baseTx)
+	return b.BuildAndSendWithAccountContext(ctx, sender.String(), accountNumber, sequence, []sdk.Msg{msg}, baseTx)
 }
 
 func (b bankClient) MultiSend(request MultiSendRequest, baseTx sdk.BaseTx) (resTxs []sdk.ResultTx, err sdk.Error) {
+	return b.MultiSendContext(context.Background(), request, baseTx)
+}
+
+func (b bankClient) MultiSendContext(ctx context.Context, request MultiSendRequest, baseTx sdk.BaseTx) (resTxs []sdk.ResultTx, err sdk.Error) {
 	sender, err := b.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return nil, sdk.Wrapf("%s not found", baseTx.From)
 	}
 
-	if len(request.Receipts) > maxMsgLen {
-		return b.SendBatch(sender, request, baseTx)
+	return b.SendBatchContext(ctx, sender, request, baseTx)
+}
+
+// MultiSendMsg returns one msg sending to all the receipts from the account `from`,
+// unlike MultiSend the receipts are not split into several msgs
+func (b bankClient) MultiSendMsg(from string, request MultiSendRequest) (sdk.Msg, sdk.Error) {
+	return b.MultiSendMsgContext(context.Background(), from, request)
+}
+
+func (b bankClient) MultiSendMsgContext(ctx context.Context, from string, request MultiSendRequest) (sdk.Msg, sdk.Error) {
+	sender, err := sdk.AccAddressFromBech32(from)
+	if err != nil {
+		return nil, sdk.Wrapf(fmt.Sprintf("%s invalid address", from))
 	}
 
 	var inputs = make([]Input, len(request.Receipts))
 	var outputs = make([]Output, len(request.Receipts))
 	for i, receipt := range request.Receipts {
-		amt, err := b.ToMinCoin(receipt.Amount...)
+		amt, err := b.ToMinCoinContext(ctx, receipt.Amount...)
 		if err != nil {
 			return nil, sdk.Wrap(err)
 		}
@@ -128,42 +170,39 @@ func (b bankClient) MultiSend(request MultiSendRequest, baseTx sdk.BaseTx) (resT
 		outputs[i] = NewOutput(outAddr, amt)
 	}
 
-	msg := NewMsgMultiSend(inputs, outputs)
-	res, err := b.BuildAndSend([]sdk.Msg{msg}, baseTx)
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-
-	resTxs = append(resTxs, res)
-	return
+	return NewMsgMultiSend(inputs, outputs), nil
 }
 
 func (b bankClient) SendBatch(sender sdk.AccAddress,
 	request MultiSendRequest, baseTx sdk.BaseTx) ([]sdk.ResultTx, sdk.Error) {
-	batchReceipts := utils.SubArray(maxMsgLen, request)
-
-	var msgs sdk.Msgs
-	for _, receipts := range batchReceipts {
-		req := receipts.(MultiSendRequest)
-		var inputs = make([]Input, len(req.Receipts))
-		var outputs = make([]Output, len(req.Receipts))
-		for i, receipt := range req.Receipts {
-			amt, err := b.ToMinCoin(receipt.Amount...)
-			if err != nil {
-				return nil, sdk.Wrap(err)
-			}
+	return b.SendBatchContext(context.Background(), sender, request, baseTx)
+}
 
-			outAddr, e := sdk.AccAddressFromBech32(receipt.Address)
-			if e != nil {
-				return nil, sdk.Wrapf(fmt.Sprintf("%s invalid address", receipt.Address))
-			}
+// SendBatchContext splits the receipts into msgs fitting in a tx each, according to the consensus params
+func (b bankClient) SendBatchContext(ctx context.Context, sender sdk.AccAddress,
+	request MultiSendRequest, baseTx sdk.BaseTx) ([]sdk.ResultTx, sdk.Error) {
+	// the size of a msg of one receipt bounds the size the receipt adds to a msg of several
+	receipts := make([]sdk.Msg, len(request.Receipts))
+	for i := range request.Receipts {
+		msg, err := b.MultiSendMsgContext(ctx, sender.String(), MultiSendRequest{Receipts: request.Receipts[i : i+1]})
+		if err != nil {
+			return nil, err
+		}
+		receipts[i] = msg
+	}
 
-			inputs[i] = NewInput(sender, amt)
-			outputs[i] = NewOutput(outAddr, amt)
+	var msgs sdk.Msgs
+	for len(receipts) > 0 {
+		batch := b.BatchSize(ctx, receipts)
+		msg := &MsgMultiSend{}
+		for _, receipt := range receipts[:batch] {
+			msg.Inputs = append(msg.Inputs, receipt.(*MsgMultiSend).Inputs...)
+			msg.Outputs = append(msg.Outputs, receipt.(*MsgMultiSend).Outputs...)
 		}
-		msgs = append(msgs, NewMsgMultiSend(inputs, outputs))
+		msgs = append(msgs, msg)
+		receipts = receipts[batch:]
 	}
-	return b.BaseClient.SendBatch(msgs, baseTx)
+	return b.BaseClient.SendBatchContext(ctx, msgs, baseTx)
 }
 
 // SubscribeSendTx Subscribe MsgSend event and return subscription
@@ -0,0 +1,54 @@
+package bank
+
+import (
+	sdk "plugchain-sdk-go/types"
+)
+
+// TransferEvent is emitted for every transfer of coins between two accounts
+type TransferEvent struct {
+	Recipient string    `event:"recipient"`
+	Sender    string    `event:"sender"`
+	Amount    sdk.Coins `event:"amount"`
+}
+
+func (TransferEvent) EventType() string { return "transfer" }
+
+// CoinSpentEvent is emitted when coins are removed from an account
+type CoinSpentEvent struct {
+	Spender string    `event:"spender"`
+	Amount  sdk.Coins `event:"amount"`
+}
+
+func (CoinSpentEvent) EventType() string { return "coin_spent" }
+
+// CoinReceivedEvent is emitted when coins are added to an account
+type CoinReceivedEvent struct {
+	Receiver string    `event:"receiver"`
+	Amount   sdk.Coins `event:"amount"`
+}
+
+func (CoinReceivedEvent) EventType() string { return "coin_received" }
+
+// CoinbaseEvent is emitted when coins are minted
+type CoinbaseEvent struct {
+	Minter string    `event:"minter"`
+	Amount sdk.Coins `event:"amount"`
+}
+
+func (CoinbaseEvent) EventType() string { return "coinbase" }
+
+// BurnEvent is emitted when coins are burnt
+type BurnEvent struct {
+	Burner string    `event:"burner"`
+	Amount sdk.Coins `event:"amount"`
+}
+
+func (BurnEvent) EventType() string { return "burn" }
+
+func init() {
+	sdk.RegisterEvent(TransferEvent{})
+	sdk.RegisterEvent(CoinSpentEvent{})
+	sdk.RegisterEvent(CoinReceivedEvent{})
+	sdk.RegisterEvent(CoinbaseEvent{})
+	sdk.RegisterEvent(BurnEvent{})
+}
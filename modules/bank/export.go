@@ -1,6 +1,8 @@
 package bank
 
 import (
+	"context"
+
 	sdk "plugchain-sdk-go/types"
 )
 
@@ -13,8 +15,25 @@ type Client interface {
 	MultiSend(receipts MultiSendRequest, baseTx sdk.BaseTx) ([]sdk.ResultTx, sdk.Error)
 	SubscribeSendTx(from, to string, callback EventMsgSendCallback) sdk.Subscription
 
+	// the msgs of the above methods, to be sent together with the msgs of other modules
+	SendMsg(from, to string, amount sdk.DecCoins) (sdk.Msg, sdk.Error)
+	MultiSendMsg(from string, receipts MultiSendRequest) (sdk.Msg, sdk.Error)
+
 	QueryAccount(address string) (sdk.BaseAccount, sdk.Error)
 	TotalSupply() (sdk.Coins, sdk.Error)
+	QueryBalances(address string) (sdk.Coins, sdk.Error)
+
+	SendContext(ctx context.Context, to string, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	SendWitchSpecAccountInfoContext(ctx context.Context, to string, sequence, accountNumber uint64, amount sdk.DecCoins, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	MultiSendContext(ctx context.Context, receipts MultiSendRequest, baseTx sdk.BaseTx) ([]sdk.ResultTx, sdk.Error)
+	SendMsgContext(ctx context.Context, from, to string, amount sdk.DecCoins) (sdk.Msg, sdk.Error)
+	MultiSendMsgContext(ctx context.Context, from string, receipts MultiSendRequest) (sdk.Msg, sdk.Error)
+	QueryAccountContext(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error)
+	TotalSupplyContext(ctx context.Context) (sdk.Coins, sdk.Error)
+	QueryBalancesContext(ctx context.Context, address string) (sdk.Coins, sdk.Error)
+
+	// the list queries walking every page, see sdk.PageIterator
+	IterateBalances(ctx context.Context, address string, size uint64) BalanceIterator
 }
 
 type Receipt struct {
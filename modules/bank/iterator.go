@@ -0,0 +1,58 @@
+package bank
+
+import (
+	"context"
+
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/query"
+)
+
+// listPageSize is the size of the pages fetched by the queries returning all the items of a list
+const listPageSize = 100
+
+// BalanceIterator walks the balances of every page
+type BalanceIterator struct {
+	*sdk.PageIterator
+}
+
+func (it BalanceIterator) Balance() sdk.Coin {
+	return it.Item().(sdk.Coin)
+}
+
+// All returns the balances of the pages left
+func (it BalanceIterator) All() (sdk.Coins, sdk.Error) {
+	var balances sdk.Coins
+	for it.Next() {
+		balances = append(balances, it.Balance())
+	}
+	return balances, it.Err()
+}
+
+// QueryBalances returns all the balances of an account
+func (b bankClient) QueryBalances(address string) (sdk.Coins, sdk.Error) {
+	return b.QueryBalancesContext(context.Background(), address)
+}
+
+func (b bankClient) QueryBalancesContext(ctx context.Context, address string) (sdk.Coins, sdk.Error) {
+	return b.IterateBalances(ctx, address, listPageSize).All()
+}
+
+// IterateBalances walks the balances of an account by pages of size
+func (b bankClient) IterateBalances(ctx context.Context, address string, size uint64) BalanceIterator {
+	return BalanceIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := b.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).AllBalances(ctx, &QueryAllBalancesRequest{Address: address, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Balances))
+		for i, c := range res.Balances {
+			items[i] = c
+		}
+		return items, res.Pagination, nil
+	})}
+}
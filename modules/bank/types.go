@@ -8,7 +8,6 @@ import (
 )
 
 const (
-	maxMsgLen  = 5
 	ModuleName = "bank"
 
 	TypeMsgSend      = "send"
@@ -17,27 +17,33 @@ import (
 	"plugchain-sdk-go/codec"
 	sdk "plugchain-sdk-go/types"
 	tx "plugchain-sdk-go/types/tx"
-	"plugchain-sdk-go/utils"
 	"plugchain-sdk-go/utils/cache"
 	sdklog "plugchain-sdk-go/utils/log"
 )
 
 const (
-	concurrency       = 16
-	cacheCapacity     = 100
-	cacheExpirePeriod = 1 * time.Minute
-	tryThreshold      = 3
-	maxBatch          = 100
+	cacheCapacity      = 100
+	cacheExpirePeriod  = 1 * time.Minute
+	tryThreshold       = 3
+	maxBatch           = 100
+	waitTxPollInterval = time.Second
+	// blockTimeCacheCapacity is the number of block times kept, they never change
+	blockTimeCacheCapacity = 10000
+	// blockchainInfoMaxHeights is the most headers returned by the node in a request
+	blockchainInfoMaxHeights = 20
 )
 
 type baseClient struct {
 	sdk.TmClient
 	sdk.GRPCClient
 	sdk.KeyManager
-	logger         log.Logger
-	cfg            *sdk.ClientConfig
-	encodingConfig sdk.EncodingConfig
-	l              *locker
+	logger          log.Logger
+	cfg             *sdk.ClientConfig
+	encodingConfig  sdk.EncodingConfig
+	sequences       *sequenceManager
+	minGasPrices    *minGasPrices
+	consensusParams *consensusParams
+	blockTimeCache  cache.Cache
 
 	accountQuery
 	tokenQuery
@@ -53,13 +59,30 @@ func NewBaseClient(cfg sdk.ClientConfig, encodingConfig sdk.EncodingConfig, logg
 		})
 	}
 
+	rpcPool, err := NewRPCPool(cfg.NodeURLList(), cfg.ChainId, encodingConfig.Amino,
+		encodingConfig.TxConfig.TxDecoder(), logger, cfg.Timeout, cfg.HealthCheckInterval)
+	if err != nil {
+		panic(err)
+	}
+	rpcPool.configureSubscriptions(cfg)
+
+	grpcAddrs := cfg.GRPCAddrList()
+	grpcClient := NewGRPCClientWithAddrs(grpcAddrs, cfg.GRPCPoolSize, grpcDialOptions(cfg)...)
+	// the grpc addresses are assumed to belong to the rpc nodes of the same index
+	if len(grpcAddrs) > 1 && len(grpcAddrs) == len(cfg.NodeURLList()) {
+		rpcPool.OnHealthChange(grpcClient.SetHealthy)
+	}
+
 	base := baseClient{
-		TmClient:       NewRPCClient(cfg.NodeURL, encodingConfig.Amino, encodingConfig.TxConfig.TxDecoder(), logger, cfg.Timeout),
-		GRPCClient:     NewGRPCClient(cfg.GRPCAddr),
-		logger:         logger,
-		cfg:            &cfg,
-		encodingConfig: encodingConfig,
-		l:              NewLocker(concurrency),
+		TmClient:        rpcPool,
+		GRPCClient:      grpcClient,
+		logger:          logger,
+		cfg:             &cfg,
+		encodingConfig:  encodingConfig,
+		sequences:       newSequenceManager(),
+		minGasPrices:    &minGasPrices{},
+		consensusParams: &consensusParams{},
+		blockTimeCache:  cache.NewCache(blockTimeCacheCapacity, true),
 	}
 
 	base.KeyManager = keyManager{
@@ -99,13 +122,28 @@ func (base *baseClient) SetLogger(logger log.Logger) {
 	base.logger = logger
 }
 
+//Close releases the grpc connections and stops the tendermint rpc client
+func (base *baseClient) Close() error {
+	err := base.GRPCClient.Close()
+	if s, ok := base.TmClient.(interface{ Stop() error }); ok {
+		if e := s.Stop(); e != nil && err == nil {
+			err = e
+		}
+	}
+	return err
+}
+
 //Returns the compiled decoder
 func (base *baseClient) Marshaler() codec.Marshaler {
 	return base.encodingConfig.Marshaler
 }
 
 func (base *baseClient) BuildTxHash(msg []sdk.Msg, baseTx sdk.BaseTx) (string, sdk.Error) {
-	txByte, _, err := base.buildTx(msg, baseTx)
+	return base.BuildTxHashContext(context.Background(), msg, baseTx)
+}
+
+func (base *baseClient) BuildTxHashContext(ctx context.Context, msg []sdk.Msg, baseTx sdk.BaseTx) (string, sdk.Error) {
+	txByte, _, err := base.buildTx(ctx, msg, baseTx)
 	if err != nil {
 		return "", sdk.Wrap(err)
 	}
@@ -113,11 +151,23 @@ func (base *baseClient) BuildTxHash(msg []sdk.Msg, baseTx sdk.BaseTx) (string, s
 }
 
 func (base *baseClient) BuildAndSign(msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte, sdk.Error) {
-	builder, err := base.prepare(baseTx)
+	return base.BuildAndSignContext(context.Background(), msg, baseTx)
+}
+
+func (base *baseClient) BuildAndSignContext(ctx context.Context, msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte, sdk.Error) {
+	builder, err := base.prepare(ctx, baseTx)
 	if err != nil {
 		return nil, sdk.Wrap(err)
 	}
 
+	if err := base.simulateGas(ctx, builder, baseTx.From, msg); err != nil {
+		return nil, err
+	}
+
+	if err := base.minGasPrices.check(builder); err != nil {
+		return nil, err
+	}
+
 	txByte, err := builder.BuildAndSign(baseTx.From, msg, true)
 	if err != nil {
 		return nil, sdk.Wrap(err)
@@ -128,40 +178,72 @@ func (base *baseClient) BuildAndSign(msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte,
 }
 
 func (base *baseClient) BuildAndSend(msg []sdk.Msg, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
-	txByte, ctx, err := base.buildTx(msg, baseTx)
+	return base.BuildAndSendContext(context.Background(), msg, baseTx)
+}
+
+func (base *baseClient) BuildAndSendContext(ctx context.Context, msg []sdk.Msg, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	addr, err := base.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, err
 	}
 
-	if err := base.ValidateTxSize(len(txByte), msg); err != nil {
-		return sdk.ResultTx{}, err
+	if baseTx.AccountNumber != 0 && baseTx.Sequence != 0 {
+		return base.BuildAndSendWithAccountContext(ctx, addr.String(), baseTx.AccountNumber, baseTx.Sequence, msg, baseTx)
 	}
 
-	res, err := base.broadcastTx(txByte, ctx.Mode(), baseTx.Simulate)
+	seq, payer, err := base.acquireSigners(ctx, addr.String(), baseTx)
 	if err != nil {
-		if base.cfg.Cached {
-			_ = base.removeCache(ctx.Address())
-		}
+		return sdk.ResultTx{}, err
+	}
+	defer unlockSigners(seq, payer)
+
+	res, _, err := base.sendWithSequence(ctx, seq, payer, msg, baseTx)
+	return res, err
+}
+
+func (base *baseClient) NewTx() *sdk.MultiMsgTx {
+	return sdk.NewMultiMsgTx(base)
+}
+
+func (base *baseClient) BroadcastAndWait(msg []sdk.Msg, baseTx sdk.BaseTx, timeout time.Duration) (sdk.ResultTx, sdk.Error) {
+	return base.BroadcastAndWaitContext(context.Background(), msg, baseTx, timeout)
+}
 
-		base.Logger().Error("broadcast transaction failed", "errMsg", err.Error())
+// BroadcastAndWaitContext sends the tx and waits until it is included in a block,
+// the timeout only applies to the wait
+func (base *baseClient) BroadcastAndWaitContext(ctx context.Context, msg []sdk.Msg, baseTx sdk.BaseTx, timeout time.Duration) (sdk.ResultTx, sdk.Error) {
+	res, err := base.BuildAndSendContext(ctx, msg, baseTx)
+	if err != nil || baseTx.Simulate || res.Height > 0 {
 		return res, err
 	}
-	return res, nil
+	return base.WaitForTxContext(ctx, res.Hash, timeout)
 }
 
 func (base *baseClient) BuildAndSendWithAccount(addr string, accountNumber, sequence uint64, msg []sdk.Msg, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
-	txByte, ctx, err := base.buildTxWithAccount(addr, accountNumber, sequence, msg, baseTx)
+	return base.BuildAndSendWithAccountContext(context.Background(), addr, accountNumber, sequence, msg, baseTx)
+}
+
+func (base *baseClient) BuildAndSendWithAccountContext(ctx context.Context, addr string, accountNumber, sequence uint64, msg []sdk.Msg, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	txByte, builder, err := base.buildTxWithAccount(ctx, addr, accountNumber, sequence, msg, baseTx, nil)
 	if err != nil {
 		return sdk.ResultTx{}, err
 	}
 
-	if err := base.ValidateTxSize(len(txByte), msg); err != nil {
+	if err := base.ValidateTxSize(ctx, txByte, builder.Gas()); err != nil {
 		return sdk.ResultTx{}, err
 	}
-	return base.broadcastTx(txByte, ctx.Mode(), baseTx.Simulate)
+	res, err := base.broadcastTx(ctx, txByte, builder.Mode(), baseTx.Simulate)
+	if err != nil {
+		base.minGasPrices.learn(err, builder.Gas())
+	}
+	return res, err
 }
 
 func (base *baseClient) SendBatch(msgs sdk.Msgs, baseTx sdk.BaseTx) (rs []sdk.ResultTx, err sdk.Error) {
+	return base.SendBatchContext(context.Background(), msgs, baseTx)
+}
+
+func (base *baseClient) SendBatchContext(ctx context.Context, msgs sdk.Msgs, baseTx sdk.BaseTx) (rs []sdk.ResultTx, err sdk.Error) {
 	if msgs == nil || len(msgs) == 0 {
 		return rs, sdk.Wrapf("must have at least one message in list")
 	}
@@ -177,58 +259,87 @@ func (base *baseClient) SendBatch(msgs sdk.Msgs, baseTx sdk.BaseTx) (rs []sdk.Re
 	}
 	base.Logger().Debug("validate msg success")
 
-	// lock the account
-	base.l.Lock(baseTx.From)
-	defer base.l.Unlock(baseTx.From)
+	addr, err := base.QueryAddress(baseTx.From, baseTx.Password)
+	if err != nil {
+		return rs, err
+	}
 
-	batch := maxBatch
-	var tryCnt = 0
+	// lock the account and the fee payer
+	seq, payer, err := base.acquireSigners(ctx, addr.String(), baseTx)
+	if err != nil {
+		return rs, err
+	}
+	defer unlockSigners(seq, payer)
+
+	for len(msgs) > 0 {
+		// the batch is halved until the signed tx is small enough
+		batch := base.BatchSize(ctx, msgs)
+		for {
+			res, tooLarge, err := base.sendWithSequence(ctx, seq, payer, msgs[:batch], baseTx)
+			if tooLarge && batch > 1 {
+				base.Logger().Debug("tx is too large", "msgsLength", batch, "errMsg", err.Error())
+				batch = batch / 2
+				continue
+			}
 
-resize:
-	for i, ms := range utils.SubArray(batch, msgs) {
-		mss := ms.(sdk.Msgs)
+			if err != nil {
+				return rs, err
+			}
+			rs = append(rs, res)
+			msgs = msgs[batch:]
 
-	retry:
-		txByte, ctx, err := base.buildTx(mss, baseTx)
-		if err != nil {
-			return rs, err
+			base.Logger().Info("broadcast transaction success", "txHash", res.Hash, "height", res.Height)
+			break
 		}
+	}
+	return rs, nil
+}
 
-		if err := base.ValidateTxSize(len(txByte), mss); err != nil {
-			base.Logger().Debug("tx is too large", "msgsLength", batch, "errMsg", err.Error())
+// sendWithSequence signs msgs with the locked sequences of the signer and of the fee payer, if any,
+// and broadcasts them, the tx is signed again if the node expects another sequence. tooLarge reports
+// whether the tx was not sent because of its size.
+func (base *baseClient) sendWithSequence(ctx context.Context, seq, payer *accountSequence, msgs []sdk.Msg,
+	baseTx sdk.BaseTx) (res sdk.ResultTx, tooLarge bool, err sdk.Error) {
+	for tryCnt := 1; ; tryCnt++ {
+		txByte, builder, err := base.buildTxWithAccount(ctx, seq.address, seq.accountNumber, seq.sequence, msgs, baseTx, payer)
+		if err != nil {
+			return res, false, err
+		}
 
-			// filter out transactions that have been sent
-			msgs = msgs[i*batch:]
-			// reset the maximum number of msg in each transaction
-			batch = batch / 2
-			_ = base.removeCache(ctx.Address())
-			goto resize
+		if err := base.ValidateTxSize(ctx, txByte, builder.Gas()); err != nil {
+			return res, true, err
 		}
 
-		res, err := base.broadcastTx(txByte, ctx.Mode(), baseTx.Simulate)
+		res, err = base.broadcastTx(ctx, txByte, builder.Mode(), baseTx.Simulate)
 		if err != nil {
-			if base.cfg.Cached {
-				base.Logger().Debug("something wrong,retrying ...", "address", ctx.Address(), "tryCnt", tryCnt)
-
-				_ = base.removeCache(ctx.Address())
-				if tryCnt++; tryCnt >= tryThreshold {
-					return rs, err
-				}
-				goto retry
-			}
+			base.minGasPrices.learn(err, builder.Gas())
+		}
 
-			base.Logger().Error("broadcast transaction failed", "errMsg", err.Error())
-			return rs, err
+		retry := seq.update(err, baseTx.Simulate)
+		if payer != nil {
+			retry = payer.update(err, baseTx.Simulate) || retry
+		}
+
+		if retry && tryCnt < tryThreshold && ctx.Err() == nil {
+			base.Logger().Debug("account sequence mismatch, retrying ...", "address", seq.address,
+				"sequence", seq.sequence, "tryCnt", tryCnt)
+			continue
 		}
-		rs = append(rs, res)
 
-		base.Logger().Info("broadcast transaction success", "txHash", res.Hash, "height", res.Height)
+		if err != nil {
+			base.Logger().Error("broadcast transaction failed", "errMsg", err.Error())
+		}
+		// the node may enforce a smaller size than the consensus params, e.g. the max size of its mempool
+		return res, errors.Is(err, sdk.ErrTxTooLarge), err
 	}
-	return rs, nil
 }
 
 func (base baseClient) QueryWithResponse(path string, data interface{}, result sdk.Response) error {
-	res, err := base.Query(path, data)
+	return base.QueryWithResponseContext(context.Background(), path, data, result)
+}
+
+func (base baseClient) QueryWithResponseContext(ctx context.Context, path string, data interface{}, result sdk.Response) error {
+	res, err := base.QueryContext(ctx, path, data)
 	if err != nil {
 		return err
 	}
@@ -241,6 +352,10 @@ func (base baseClient) QueryWithResponse(path string, data interface{}, result s
 }
 
 func (base baseClient) Query(path string, data interface{}) ([]byte, error) {
+	return base.QueryContext(context.Background(), path, data)
+}
+
+func (base baseClient) QueryContext(ctx context.Context, path string, data interface{}) ([]byte, error) {
 	var bz []byte
 	var err error
 	if data != nil {
@@ -251,10 +366,10 @@ func (base baseClient) Query(path string, data interface{}) ([]byte, error) {
 	}
 
 	opts := rpcclient.ABCIQueryOptions{
-		// Height: cliCtx.Height,
-		Prove: false,
+		Height: base.queryHeight(ctx),
+		Prove:  false,
 	}
-	result, err := base.ABCIQueryWithOptions(context.Background(), path, bz, opts)
+	result, err := base.ABCIQueryWithOptions(ctx, path, bz, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -264,17 +379,26 @@ func (base baseClient) Query(path string, data interface{}) ([]byte, error) {
 		return nil, errors.New(resp.Log)
 	}
 
+	sdk.RecordQueryHeight(ctx, resp.Height)
 	return resp.Value, nil
 }
 
 func (base baseClient) QueryStore(key sdk.HexBytes, storeName string, height int64, prove bool) (res abci.ResponseQuery, err error) {
+	return base.QueryStoreContext(context.Background(), key, storeName, height, prove)
+}
+
+// QueryStoreContext queries key in the store at height, 0 queries the height the queries made with ctx are pinned to
+func (base baseClient) QueryStoreContext(ctx context.Context, key sdk.HexBytes, storeName string, height int64, prove bool) (res abci.ResponseQuery, err error) {
+	if height == 0 {
+		height = base.queryHeight(ctx)
+	}
 	path := fmt.Sprintf("/store/%s/%s", storeName, "key")
 	opts := rpcclient.ABCIQueryOptions{
 		Prove:  prove,
 		Height: height,
 	}
 
-	result, err := base.ABCIQueryWithOptions(context.Background(), path, key, opts)
+	result, err := base.ABCIQueryWithOptions(ctx, path, key, opts)
 	if err != nil {
 		return res, err
 	}
@@ -283,36 +407,47 @@ func (base baseClient) QueryStore(key sdk.HexBytes, storeName string, height int
 	if !resp.IsOK() {
 		return res, errors.New(resp.Log)
 	}
+	sdk.RecordQueryHeight(ctx, resp.Height)
 	return resp, nil
 }
 
-func (base *baseClient) prepareTemp(addr string, accountNumber, sequence uint64, baseTx sdk.BaseTx) (*clienttx.Factory, error) {
+// queryHeight returns the height the abci queries made with ctx are pinned to, 0 for the latest height
+func (base baseClient) queryHeight(ctx context.Context) int64 {
+	if height, ok := sdk.HeightFromContext(ctx); ok {
+		return height
+	}
+	if base.cfg != nil {
+		return base.cfg.QueryHeight
+	}
+	return 0
+}
+
+// prepareTemp prepares the tx of the account at addr, payer is the locked sequence of the fee payer,
+// if it is nil, the current sequence of the fee payer is used
+func (base *baseClient) prepareTemp(ctx context.Context, addr string, accountNumber, sequence uint64,
+	baseTx sdk.BaseTx, payer *accountSequence) (*clienttx.Factory, error) {
 	factory := clienttx.NewFactory().
 		WithChainID(base.cfg.ChainId).
 		WithKeyManager(base.KeyManager).
 		WithMode(base.cfg.Mode).
-		WithSimulateAndExecute(baseTx.Simulate).
+		WithSimulateAndExecute(base.gasAuto(baseTx)).
 		WithGas(base.cfg.Gas).
+		WithSignMode(baseTx.SignMode).
 		WithSignModeHandler(tx.MakeSignModeHandler(tx.DefaultSignModes)).
 		WithTxConfig(base.encodingConfig.TxConfig)
 
 	factory.WithAddress(addr).
 		WithAccountNumber(accountNumber).
 		WithSequence(sequence).
-		WithPassword(baseTx.Password)
+		WithPassword(baseTx.Password).
+		WithTimeoutHeight(baseTx.TimeoutHeight)
 
-	if !baseTx.Fee.Empty() && baseTx.Fee.IsValid() {
-		fees, err := base.ToMinCoin(baseTx.Fee...)
-		if err != nil {
-			return nil, err
-		}
-		factory.WithFee(fees)
-	} else {
-		fees, err := base.ToMinCoin(base.cfg.Fee...)
-		if err != nil {
-			panic(err)
-		}
-		factory.WithFee(fees)
+	if err := base.prepareFee(ctx, factory, baseTx); err != nil {
+		return nil, err
+	}
+
+	if err := base.prepareFeePayer(ctx, factory, baseTx, payer); err != nil {
+		return nil, err
 	}
 
 	if len(baseTx.Mode) > 0 {
@@ -346,18 +481,144 @@ type locker struct {
 	size   int
 }
 
-func (base *baseClient) ValidateTxSize(txSize int, msgs []sdk.Msg) sdk.Error {
-	//TODO
+// prepareFee sets the fee of the tx, in order of precedence: BaseTx.Fee, BaseTx.GasPrices,
+// ClientConfig.GasPrices and ClientConfig.Fee
+func (base *baseClient) prepareFee(ctx context.Context, factory *clienttx.Factory, baseTx sdk.BaseTx) error {
+	if !baseTx.Fee.Empty() && baseTx.Fee.IsValid() {
+		fees, err := base.ToMinCoinContext(ctx, baseTx.Fee...)
+		if err != nil {
+			return err
+		}
+		factory.WithFee(fees)
+		return nil
+	}
+
+	gasPrices := baseTx.GasPrices
+	if gasPrices.Empty() {
+		gasPrices = base.cfg.GasPrices
+	}
+	if !gasPrices.Empty() {
+		if !gasPrices.IsValid() {
+			return sdk.Wrapf("invalid gas prices: %s", gasPrices)
+		}
+		prices, err := base.toMinGasPrices(ctx, gasPrices)
+		if err != nil {
+			return err
+		}
+		factory.WithGasPrices(prices)
+		return nil
+	}
+
+	fees, err := base.ToMinCoinContext(ctx, base.cfg.Fee...)
+	if err != nil {
+		return err
+	}
+	factory.WithFee(fees)
+	return nil
+}
+
+// prepareFeePayer sets the fee granter and the fee payer of the tx, the fee payer signs the tx with
+// the sequence of payer, or with its current sequence if payer is nil
+func (base *baseClient) prepareFeePayer(ctx context.Context, factory *clienttx.Factory, baseTx sdk.BaseTx, payer *accountSequence) error {
+	if len(baseTx.FeeGranter) > 0 {
+		granter, err := sdk.AccAddressFromBech32(baseTx.FeeGranter)
+		if err != nil {
+			return sdk.Wrap(err)
+		}
+		factory.WithFeeGranter(granter)
+	}
+
+	if len(baseTx.FeePayer) == 0 {
+		return nil
+	}
+
+	addr, err := base.QueryAddress(baseTx.FeePayer, baseTx.FeePayerPassword)
+	if err != nil {
+		return err
+	}
+
+	feePayer := &clienttx.FeePayer{
+		Address:  addr,
+		Name:     baseTx.FeePayer,
+		Password: baseTx.FeePayerPassword,
+	}
+	// the signer of the msgs paying its own fees signs only once
+	if addr.String() != factory.Address() {
+		if payer == nil {
+			seq, err := base.sequences.acquire(ctx, addr.String(), base.queryLatestAccount)
+			if err != nil {
+				return err
+			}
+			feePayer.AccountNumber, feePayer.Sequence = seq.accountNumber, seq.sequence
+			seq.Unlock()
+		} else {
+			feePayer.AccountNumber, feePayer.Sequence = payer.accountNumber, payer.sequence
+		}
+	}
+	factory.WithFeePayer(feePayer)
 	return nil
 }
 
-func (base *baseClient) prepare(baseTx sdk.BaseTx) (*clienttx.Factory, error) {
+// acquireSigners locks the sequences of the signer of the msgs at addr and of the fee payer of the tx,
+// payer is nil if the tx has no fee payer or if it is the signer of the msgs. The sequences are locked
+// in the order of their addresses, so that two accounts paying the fees of each other do not deadlock.
+func (base *baseClient) acquireSigners(ctx context.Context, addr string, baseTx sdk.BaseTx) (seq, payer *accountSequence, err sdk.Error) {
+	var payerAddr string
+	if len(baseTx.FeePayer) > 0 {
+		feePayer, err := base.QueryAddress(baseTx.FeePayer, baseTx.FeePayerPassword)
+		if err != nil {
+			return nil, nil, err
+		}
+		if feePayer.String() != addr {
+			payerAddr = feePayer.String()
+		}
+	}
+	if len(payerAddr) == 0 {
+		seq, err = base.sequences.acquire(ctx, addr, base.queryLatestAccount)
+		return seq, nil, err
+	}
+
+	first, second := addr, payerAddr
+	if second < first {
+		first, second = second, first
+	}
+	firstSeq, err := base.sequences.acquire(ctx, first, base.queryLatestAccount)
+	if err != nil {
+		return nil, nil, err
+	}
+	secondSeq, err := base.sequences.acquire(ctx, second, base.queryLatestAccount)
+	if err != nil {
+		firstSeq.Unlock()
+		return nil, nil, err
+	}
+	if first == addr {
+		return firstSeq, secondSeq, nil
+	}
+	return secondSeq, firstSeq, nil
+}
+
+// unlockSigners unlocks the sequences locked by acquireSigners
+func unlockSigners(seq, payer *accountSequence) {
+	if payer != nil {
+		payer.Unlock()
+	}
+	seq.Unlock()
+}
+
+// gasAuto returns whether the gas limit of the tx is estimated by a simulation,
+// a gas set on the BaseTx takes precedence over ClientConfig.GasAuto
+func (base *baseClient) gasAuto(baseTx sdk.BaseTx) bool {
+	return baseTx.GasAuto || (base.cfg.GasAuto && baseTx.Gas == 0)
+}
+
+func (base *baseClient) prepare(ctx context.Context, baseTx sdk.BaseTx) (*clienttx.Factory, error) {
 	factory := clienttx.NewFactory().
 		WithChainID(base.cfg.ChainId).
 		WithKeyManager(base.KeyManager).
 		WithMode(base.cfg.Mode).
-		WithSimulateAndExecute(baseTx.Simulate).
+		WithSimulateAndExecute(base.gasAuto(baseTx)).
 		WithGas(base.cfg.Gas).
+		WithSignMode(baseTx.SignMode).
 		WithSignModeHandler(tx.MakeSignModeHandler(tx.DefaultSignModes)).
 		WithTxConfig(base.encodingConfig.TxConfig)
 
@@ -372,27 +633,23 @@ func (base *baseClient) prepare(baseTx sdk.BaseTx) (*clienttx.Factory, error) {
 			WithSequence(baseTx.Sequence).
 			WithPassword(baseTx.Password)
 	} else {
-		account, err := base.QueryAndRefreshAccount(addr.String())
+		seq, err := base.sequences.acquire(ctx, addr.String(), base.queryLatestAccount)
 		if err != nil {
 			return nil, err
 		}
-		factory.WithAccountNumber(account.AccountNumber).
-			WithSequence(account.Sequence).
+		factory.WithAccountNumber(seq.accountNumber).
+			WithSequence(seq.sequence).
 			WithPassword(baseTx.Password)
+		seq.Unlock()
 	}
+	factory.WithTimeoutHeight(baseTx.TimeoutHeight)
 
-	if !baseTx.Fee.Empty() && baseTx.Fee.IsValid() {
-		fees, err := base.ToMinCoin(baseTx.Fee...)
-		if err != nil {
-			return nil, err
-		}
-		factory.WithFee(fees)
-	} else {
-		fees, err := base.ToMinCoin(base.cfg.Fee...)
-		if err != nil {
-			panic(err)
-		}
-		factory.WithFee(fees)
+	if err := base.prepareFee(ctx, factory, baseTx); err != nil {
+		return nil, err
+	}
+
+	if err := base.prepareFeePayer(ctx, factory, baseTx, nil); err != nil {
+		return nil, err
 	}
 
 	if len(baseTx.Mode) > 0 {
@@ -34,46 +34,84 @@ func (swap coinswapClient) RegisterInterfaceTypes(registry cdctypes.InterfaceReg
 	RegisterInterfaces(registry)
 }
 
+func (swap coinswapClient) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	RegisterLegacyAminoCodec(cdc)
+}
+
 func (swap coinswapClient) AddLiquidity(request AddLiquidityRequest,
+	baseTx sdk.BaseTx) (sdk.ResultTx, error) {
+	return swap.AddLiquidityContext(context.Background(), request, baseTx)
+}
+
+func (swap coinswapClient) AddLiquidityContext(ctx context.Context, request AddLiquidityRequest,
 	baseTx sdk.BaseTx) (sdk.ResultTx, error) {
 	creator, err := swap.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	msg := &MsgCreatePool{
-		PoolCreatorAddress: creator.String(),
-		PoolTypeId:         1,
-		DepositCoins:       sdk.Coins{request.BaseToken, request.Token},
+	msg, e := swap.AddLiquidityMsg(creator.String(), request)
+	if e != nil {
+		return sdk.ResultTx{}, e
 	}
 
-	res, err := swap.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	res, err := swap.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
 	if err != nil {
 		return sdk.ResultTx{}, err
 	}
 	return res, nil
 }
 
+// AddLiquidityMsg returns the msg of AddLiquidity for the account `creator`
+func (swap coinswapClient) AddLiquidityMsg(creator string, request AddLiquidityRequest) (sdk.Msg, error) {
+	if err := sdk.ValidateAccAddress(creator); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	return &MsgCreatePool{
+		PoolCreatorAddress: creator,
+		PoolTypeId:         1,
+		DepositCoins:       sdk.Coins{request.BaseToken, request.Token},
+	}, nil
+}
+
 func (swap coinswapClient) DepositWithinBatch(request DepositWithinBatchRequest,
+	baseTx sdk.BaseTx) (sdk.ResultTx, error) {
+	return swap.DepositWithinBatchContext(context.Background(), request, baseTx)
+}
+
+func (swap coinswapClient) DepositWithinBatchContext(ctx context.Context, request DepositWithinBatchRequest,
 	baseTx sdk.BaseTx) (sdk.ResultTx, error) {
 	creator, err := swap.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	msg := &MsgDepositWithinBatch{
-		DepositorAddress: creator.String(),
-		PoolId:           request.PoolId,
-		DepositCoins:     sdk.Coins{request.BaseToken, request.Token},
+	msg, e := swap.DepositWithinBatchMsg(creator.String(), request)
+	if e != nil {
+		return sdk.ResultTx{}, e
 	}
 
-	res, err := swap.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	res, err := swap.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
 	if err != nil {
 		return sdk.ResultTx{}, err
 	}
 	return res, nil
 }
 
+// DepositWithinBatchMsg returns the msg of DepositWithinBatch for the account `creator`
+func (swap coinswapClient) DepositWithinBatchMsg(creator string, request DepositWithinBatchRequest) (sdk.Msg, error) {
+	if err := sdk.ValidateAccAddress(creator); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	return &MsgDepositWithinBatch{
+		DepositorAddress: creator,
+		PoolId:           request.PoolId,
+		DepositCoins:     sdk.Coins{request.BaseToken, request.Token},
+	}, nil
+}
+
 //func (swap coinswapClient) RemoveLiquidity(request RemoveLiquidityRequest,
 //	baseTx sdk.BaseTx) (*RemoveLiquidityResponse, error) {
 //	creator, err := swap.QueryAddress(baseTx.From, baseTx.Password)
@@ -120,64 +158,99 @@ func (swap coinswapClient) DepositWithinBatch(request DepositWithinBatchRequest,
 //}
 
 func (swap coinswapClient) SwapCoin(request SwapCoinRequest, baseTx sdk.BaseTx) (sdk.ResultTx, error) {
+	return swap.SwapCoinContext(context.Background(), request, baseTx)
+}
+
+func (swap coinswapClient) SwapCoinContext(ctx context.Context, request SwapCoinRequest, baseTx sdk.BaseTx) (sdk.ResultTx, error) {
 	creator, err := swap.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, err
 	}
 
-	orderPrice, e := sdk.NewDecFromStr(request.OrderPrice)
+	msg, e := swap.SwapCoinMsg(creator.String(), request)
 	if e != nil {
 		return sdk.ResultTx{}, e
 	}
-	swapFeeRate, e := sdk.NewDecFromStr(request.SwapFeeRate)
-	if e != nil {
-		return sdk.ResultTx{}, e
+
+	res, err := swap.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return res, nil
+}
+
+// SwapCoinMsg returns the msg of SwapCoin for the account `creator`
+func (swap coinswapClient) SwapCoinMsg(creator string, request SwapCoinRequest) (sdk.Msg, error) {
+	if err := sdk.ValidateAccAddress(creator); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	orderPrice, err := sdk.NewDecFromStr(request.OrderPrice)
+	if err != nil {
+		return nil, err
+	}
+	swapFeeRate, err := sdk.NewDecFromStr(request.SwapFeeRate)
+	if err != nil {
+		return nil, err
 	}
 
-	msg := &MsgSwapWithinBatch{
-		SwapRequesterAddress: creator.String(),
+	return &MsgSwapWithinBatch{
+		SwapRequesterAddress: creator,
 		PoolId:               request.PoolId,
 		SwapTypeId:           1,
 		OfferCoin:            request.OfferCoin,
 		DemandCoinDenom:      request.DemandCoinDenom,
 		OfferCoinFee:         sdk.GetOfferCoinFee(request.OfferCoin, swapFeeRate),
 		OrderPrice:           orderPrice,
-	}
-
-	res, err := swap.BuildAndSend([]sdk.Msg{msg}, baseTx)
-	if err != nil {
-		return sdk.ResultTx{}, err
-	}
-	return res, nil
+	}, nil
 }
 
 func (swap coinswapClient) WithdrawWithin(request WithdrawWithinRequest, baseTx sdk.BaseTx) (sdk.ResultTx, error) {
+	return swap.WithdrawWithinContext(context.Background(), request, baseTx)
+}
+
+func (swap coinswapClient) WithdrawWithinContext(ctx context.Context, request WithdrawWithinRequest, baseTx sdk.BaseTx) (sdk.ResultTx, error) {
 	creator, err := swap.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, err
 	}
 
-	msg := &MsgWithdrawWithinBatch{
-		WithdrawerAddress: creator.String(),
-		PoolId:            request.PoolId,
-		PoolCoin:          request.PoolCoin,
+	msg, e := swap.WithdrawWithinMsg(creator.String(), request)
+	if e != nil {
+		return sdk.ResultTx{}, e
 	}
-	res, err := swap.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	res, err := swap.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
 	if err != nil {
 		return sdk.ResultTx{}, err
 	}
 	return res, nil
 }
 
+// WithdrawWithinMsg returns the msg of WithdrawWithin for the account `creator`
+func (swap coinswapClient) WithdrawWithinMsg(creator string, request WithdrawWithinRequest) (sdk.Msg, error) {
+	if err := sdk.ValidateAccAddress(creator); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	return &MsgWithdrawWithinBatch{
+		WithdrawerAddress: creator,
+		PoolId:            request.PoolId,
+		PoolCoin:          request.PoolCoin,
+	}, nil
+}
+
 func (swap coinswapClient) WithdrawWithinBatch(request WithdrawWithinBatchRequest) (interface{}, error) {
+	return swap.WithdrawWithinBatchContext(context.Background(), request)
+}
+
+func (swap coinswapClient) WithdrawWithinBatchContext(ctx context.Context, request WithdrawWithinBatchRequest) (interface{}, error) {
 	conn, err := swap.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return nil, sdk.Wrap(err)
 	}
 
 	resp, err := NewQueryClient(conn).LiquidityPoolBatch(
-		context.Background(),
+		ctx,
 		&QueryLiquidityPoolBatchRequest{PoolId: request.PoolId},
 	)
 	if err != nil {
@@ -247,14 +320,17 @@ func (swap coinswapClient) WithdrawWithinBatch(request WithdrawWithinBatchReques
 //}
 
 func (swap coinswapClient) PoolBatchDeposit(request PoolBatchDepositMsg) (interface{}, error) {
+	return swap.PoolBatchDepositContext(context.Background(), request)
+}
+
+func (swap coinswapClient) PoolBatchDepositContext(ctx context.Context, request PoolBatchDepositMsg) (interface{}, error) {
 	conn, err := swap.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return nil, sdk.Wrap(err)
 	}
 
 	resp, err := NewQueryClient(conn).PoolBatchDepositMsg(
-		context.Background(),
+		ctx,
 		&QueryPoolBatchDepositMsgRequest{
 			PoolId:   request.PoolId,
 			MsgIndex: request.MsgIndex,
@@ -268,7 +344,6 @@ func (swap coinswapClient) PoolBatchDeposit(request PoolBatchDepositMsg) (interf
 
 //func (swap coinswapClient) QueryPool(lptDenom string) (*QueryPoolResponse, error) {
 //	conn, err := swap.GenConn()
-//	defer func() { _ = conn.Close() }()
 //	if err != nil {
 //		return nil, sdk.Wrap(err)
 //	}
@@ -284,14 +359,17 @@ func (swap coinswapClient) PoolBatchDeposit(request PoolBatchDepositMsg) (interf
 //}
 
 func (swap coinswapClient) QueryAllPools(req sdk.PageRequest) (interface{}, error) {
+	return swap.QueryAllPoolsContext(context.Background(), req)
+}
+
+func (swap coinswapClient) QueryAllPoolsContext(ctx context.Context, req sdk.PageRequest) (interface{}, error) {
 	conn, err := swap.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return nil, sdk.Wrap(err)
 	}
 
 	resp, err := NewQueryClient(conn).LiquidityPools(
-		context.Background(),
+		ctx,
 		&QueryLiquidityPoolsRequest{
 			Pagination: &query.PageRequest{
 				Key:        req.Key,
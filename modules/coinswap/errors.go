@@ -0,0 +1,20 @@
+package coinswap
+
+import (
+	sdk "plugchain-sdk-go/types"
+)
+
+// Codespace is the codespace of the errors of the liquidity module returned by the node
+const Codespace = "liquidity"
+
+const (
+	codePoolNotExists       uint32 = 1
+	codeInsufficientBalance uint32 = 8
+	codePoolBatchNotExists  uint32 = 12
+)
+
+func init() {
+	sdk.RegisterABCIError(Codespace, codePoolNotExists, sdk.ErrNotFound)
+	sdk.RegisterABCIError(Codespace, codeInsufficientBalance, sdk.ErrInsufficientFunds)
+	sdk.RegisterABCIError(Codespace, codePoolBatchNotExists, sdk.ErrNotFound)
+}
@@ -0,0 +1,60 @@
+package coinswap
+
+import (
+	sdk "plugchain-sdk-go/types"
+)
+
+// CreatePoolEvent is emitted when a liquidity pool is created with its first deposit
+type CreatePoolEvent struct {
+	PoolID         uint64    `event:"pool_id"`
+	PoolTypeID     uint32    `event:"pool_type_id"`
+	PoolName       string    `event:"pool_name"`
+	ReserveAccount string    `event:"reserve_account"`
+	DepositCoins   sdk.Coins `event:"deposit_coins"`
+	PoolCoinDenom  string    `event:"pool_coin_denom"`
+}
+
+func (CreatePoolEvent) EventType() string { return "create_pool" }
+
+// DepositWithinBatchEvent is emitted when a deposit is added to the batch of a pool
+type DepositWithinBatchEvent struct {
+	PoolID       uint64    `event:"pool_id"`
+	BatchIndex   uint64    `event:"batch_index"`
+	MsgIndex     uint64    `event:"msg_index"`
+	DepositCoins sdk.Coins `event:"deposit_coins"`
+}
+
+func (DepositWithinBatchEvent) EventType() string { return "deposit_within_batch" }
+
+// WithdrawWithinBatchEvent is emitted when a withdrawal is added to the batch of a pool
+type WithdrawWithinBatchEvent struct {
+	PoolID         uint64  `event:"pool_id"`
+	BatchIndex     uint64  `event:"batch_index"`
+	MsgIndex       uint64  `event:"msg_index"`
+	PoolCoinDenom  string  `event:"pool_coin_denom"`
+	PoolCoinAmount sdk.Int `event:"pool_coin_amount"`
+}
+
+func (WithdrawWithinBatchEvent) EventType() string { return "withdraw_within_batch" }
+
+// SwapWithinBatchEvent is emitted when a swap order is added to the batch of a pool
+type SwapWithinBatchEvent struct {
+	PoolID             uint64  `event:"pool_id"`
+	BatchIndex         uint64  `event:"batch_index"`
+	MsgIndex           uint64  `event:"msg_index"`
+	SwapTypeID         uint32  `event:"swap_type_id"`
+	OfferCoinDenom     string  `event:"offer_coin_denom"`
+	OfferCoinAmount    sdk.Int `event:"offer_coin_amount"`
+	OfferCoinFeeAmount sdk.Int `event:"offer_coin_fee_amount"`
+	DemandCoinDenom    string  `event:"demand_coin_denom"`
+	OrderPrice         sdk.Dec `event:"order_price"`
+}
+
+func (SwapWithinBatchEvent) EventType() string { return "swap_within_batch" }
+
+func init() {
+	sdk.RegisterEvent(CreatePoolEvent{})
+	sdk.RegisterEvent(DepositWithinBatchEvent{})
+	sdk.RegisterEvent(WithdrawWithinBatchEvent{})
+	sdk.RegisterEvent(SwapWithinBatchEvent{})
+}
@@ -1,6 +1,8 @@
 package coinswap
 
 import (
+	"context"
+
 	sdk "plugchain-sdk-go/types"
 	"plugchain-sdk-go/types/query"
 )
@@ -19,6 +21,12 @@ type Client interface {
 	SwapCoin(request SwapCoinRequest,
 		baseTx sdk.BaseTx) (sdk.ResultTx, error)
 
+	// the msgs of the above methods, to be sent together with the msgs of other modules
+	AddLiquidityMsg(creator string, request AddLiquidityRequest) (sdk.Msg, error)
+	DepositWithinBatchMsg(creator string, request DepositWithinBatchRequest) (sdk.Msg, error)
+	WithdrawWithinMsg(creator string, request WithdrawWithinRequest) (sdk.Msg, error)
+	SwapCoinMsg(creator string, request SwapCoinRequest) (sdk.Msg, error)
+
 	WithdrawWithinBatch(request WithdrawWithinBatchRequest) (interface{}, error)
 	//BuyTokenWithAutoEstimate(paidTokenDenom string, boughtCoin sdk.Coin,
 	//	deadline int64,
@@ -43,6 +51,21 @@ type Client interface {
 	//EstimateBaseForBoughtToken(boughtToken sdk.Coin) (sdk.Int, error)
 	//EstimateTokenForBoughtToken(soldTokenDenom string,
 	//	boughtToken sdk.Coin) (sdk.Int, error)
+
+	AddLiquidityContext(ctx context.Context, request AddLiquidityRequest,
+		baseTx sdk.BaseTx) (sdk.ResultTx, error)
+	DepositWithinBatchContext(ctx context.Context, request DepositWithinBatchRequest,
+		baseTx sdk.BaseTx) (sdk.ResultTx, error)
+	WithdrawWithinContext(ctx context.Context, request WithdrawWithinRequest,
+		baseTx sdk.BaseTx) (sdk.ResultTx, error)
+	SwapCoinContext(ctx context.Context, request SwapCoinRequest,
+		baseTx sdk.BaseTx) (sdk.ResultTx, error)
+	WithdrawWithinBatchContext(ctx context.Context, request WithdrawWithinBatchRequest) (interface{}, error)
+	PoolBatchDepositContext(ctx context.Context, request PoolBatchDepositMsg) (interface{}, error)
+	QueryAllPoolsContext(ctx context.Context, pageReq sdk.PageRequest) (interface{}, error)
+
+	// the list queries walking every page, see sdk.PageIterator
+	IteratePools(ctx context.Context, size uint64) PoolIterator
 }
 
 type AddLiquidityRequest struct {
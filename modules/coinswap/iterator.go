@@ -0,0 +1,46 @@
+package coinswap
+
+import (
+	"context"
+
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/query"
+)
+
+// PoolIterator walks the liquidity pools of every page
+type PoolIterator struct {
+	*sdk.PageIterator
+}
+
+func (it PoolIterator) Pool() sdk.PoolInfo {
+	return it.Item().(sdk.PoolInfo)
+}
+
+// All returns the pools of the pages left
+func (it PoolIterator) All() ([]sdk.PoolInfo, sdk.Error) {
+	var pools []sdk.PoolInfo
+	for it.Next() {
+		pools = append(pools, it.Pool())
+	}
+	return pools, it.Err()
+}
+
+// IteratePools walks the liquidity pools by pages of size
+func (swap coinswapClient) IteratePools(ctx context.Context, size uint64) PoolIterator {
+	return PoolIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := swap.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).LiquidityPools(ctx, &QueryLiquidityPoolsRequest{Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Pools))
+		for i, pool := range res.Pools {
+			items[i] = _loadPoolInfo(pool)
+		}
+		return items, res.Pagination, nil
+	})}
+}
@@ -0,0 +1,109 @@
+package modules
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	codectypes "plugchain-sdk-go/codec/types"
+	sdk "plugchain-sdk-go/types"
+)
+
+const (
+	// consensusParamsExpiration is how long the consensus params are cached,
+	// they only change with a governance proposal
+	consensusParamsExpiration = 10 * time.Minute
+	// txSizeOverhead is an upper bound of the size of a tx besides its msgs,
+	// such as the signatures and the fee
+	txSizeOverhead = 1024
+)
+
+// consensusParams caches the limits of a block enforced by the node on every tx
+type consensusParams struct {
+	mtx          sync.Mutex
+	maxDataBytes int64
+	maxGas       int64
+	expires      time.Time
+}
+
+// txLimits returns the max size of a tx and the max gas of a block, the max gas is -1 if there is no limit
+func (base *baseClient) txLimits(ctx context.Context) (maxDataBytes, maxGas int64, err error) {
+	p := base.consensusParams
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	if time.Now().Before(p.expires) {
+		return p.maxDataBytes, p.maxGas, nil
+	}
+
+	params, err := base.ConsensusParams(ctx, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// the size of the txs of a block is bounded by the max bytes of the block minus its header
+	// and the commit, whose size depends on the number of validators
+	vals, err := base.Validators(ctx, &params.BlockHeight, nil, nil)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	p.maxDataBytes = tmtypes.MaxDataBytesNoEvidence(params.ConsensusParams.Block.MaxBytes, vals.Total)
+	p.maxGas = params.ConsensusParams.Block.MaxGas
+	p.expires = time.Now().Add(consensusParamsExpiration)
+	return p.maxDataBytes, p.maxGas, nil
+}
+
+// ValidateTxSize checks the encoded size and the gas of a signed tx against the consensus params,
+// the node rejects a tx exceeding them. A TxTooLargeError is returned if the tx is too large.
+func (base *baseClient) ValidateTxSize(ctx context.Context, txBytes []byte, gas uint64) sdk.Error {
+	maxDataBytes, maxGas, err := base.txLimits(ctx)
+	if err != nil {
+		// the node still rejects the tx if it is too large
+		base.Logger().Debug("query consensus params failed", "errMsg", err.Error())
+		return nil
+	}
+
+	size := tmtypes.ComputeProtoSizeForTxs([]tmtypes.Tx{txBytes})
+	if size > maxDataBytes || (maxGas >= 0 && gas > uint64(maxGas)) {
+		return sdk.TxTooLargeError{
+			Size:     size,
+			MaxBytes: maxDataBytes,
+			Gas:      gas,
+			MaxGas:   maxGas,
+		}
+	}
+	return nil
+}
+
+// BatchSize returns how many of the leading msgs fit in a tx according to their encoded size,
+// at least one msg is returned
+func (base *baseClient) BatchSize(ctx context.Context, msgs []sdk.Msg) int {
+	maxDataBytes, _, err := base.txLimits(ctx)
+	if err != nil {
+		base.Logger().Debug("query consensus params failed", "errMsg", err.Error())
+		if len(msgs) < maxBatch {
+			return len(msgs)
+		}
+		return maxBatch
+	}
+
+	size := int64(txSizeOverhead)
+	for i, msg := range msgs {
+		any, err := codectypes.NewAnyWithValue(msg)
+		if err != nil {
+			return i + 1
+		}
+		// each msg is a length-prefixed field of the tx body
+		size += int64(any.Size()) + 8
+		if size > maxDataBytes {
+			if i == 0 {
+				return 1
+			}
+			return i
+		}
+	}
+	return len(msgs)
+}
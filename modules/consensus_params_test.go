@@ -0,0 +1,60 @@
+package modules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	tmproto "github.com/tendermint/tendermint/proto/tendermint/types"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"plugchain-sdk-go/modules/bank"
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/utils/rpctest"
+)
+
+func TestValidateTxSize(t *testing.T) {
+	var queries int
+	node := rpctest.NewNode(t, map[string]func(rpctypes.RPCRequest) rpctypes.RPCResponse{
+		"status": rpctest.Result(&ctypes.ResultStatus{NodeInfo: p2p.DefaultNodeInfo{Network: "chaintest-1"}}),
+		"consensus_params": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			queries++
+			return rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultConsensusParams{
+				BlockHeight: 10,
+				ConsensusParams: tmproto.ConsensusParams{
+					Block: tmproto.BlockParams{MaxBytes: 4096, MaxGas: 200000},
+				},
+			})
+		},
+		"validators": rpctest.Result(&ctypes.ResultValidators{BlockHeight: 10, Total: 1}),
+	})
+
+	pool, err := NewRPCPool([]string{node.URL}, "chaintest-1", nil, nil, log.NewNopLogger(), 1, 0)
+	require.NoError(t, err)
+	defer pool.Stop()
+
+	base := baseClient{TmClient: pool, logger: log.NewNopLogger(), consensusParams: &consensusParams{}}
+	maxDataBytes := tmtypes.MaxDataBytesNoEvidence(4096, 1)
+
+	require.Nil(t, base.ValidateTxSize(context.Background(), make([]byte, 100), 100000))
+	require.IsType(t, sdk.TxTooLargeError{}, base.ValidateTxSize(context.Background(), make([]byte, 100), 300000))
+	require.IsType(t, sdk.TxTooLargeError{}, base.ValidateTxSize(context.Background(), make([]byte, maxDataBytes), 100000))
+	require.Equal(t, 1, queries)
+
+	msg := &bank.MsgSend{
+		FromAddress: "from",
+		ToAddress:   "to",
+		Amount:      sdk.NewCoins(sdk.NewCoin("plug", sdk.NewInt(1))),
+	}
+	msgs := make([]sdk.Msg, 100)
+	for i := range msgs {
+		msgs[i] = msg
+	}
+	batch := base.BatchSize(context.Background(), msgs)
+	require.Greater(t, batch, 1)
+	require.Less(t, batch, len(msgs))
+}
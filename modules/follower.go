@@ -0,0 +1,249 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "plugchain-sdk-go/types"
+)
+
+const (
+	defaultFollowName        = "default"
+	defaultFollowConcurrency = 4
+	// followRetryInterval is the wait before fetching the blocks again after a failure of the node
+	followRetryInterval = 3 * time.Second
+	// followPollInterval is the wait between two checks of the latest height when no websocket is available
+	followPollInterval = waitTxPollInterval
+	// followLiveTimeout is the longest wait for a new block event before checking the latest height
+	followLiveTimeout = 30 * time.Second
+)
+
+// handlerError is the error of a BlockHandler, which stops the follower
+type handlerError struct {
+	err error
+}
+
+func (e handlerError) Error() string {
+	return e.err.Error()
+}
+
+// FollowBlocks catches up with the latest height by fetching the blocks in parallel, then it waits for the
+// new blocks through the websocket, or by polling when no websocket is available. Every block, past or new,
+// is fetched by its height, so that no block is missed when switching to the live blocks or reconnecting.
+// The failures of the node are retried, the follower only stops when ctx is done or the handler fails.
+func (base baseClient) FollowBlocks(ctx context.Context, opts sdk.FollowOptions, handler sdk.BlockHandler) sdk.Error {
+	if handler == nil {
+		return sdk.Wrapf("block handler is required")
+	}
+	if len(opts.Name) == 0 {
+		opts.Name = defaultFollowName
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = defaultFollowConcurrency
+	}
+
+	next, err := base.followStart(ctx, opts)
+	if err != nil {
+		return sdk.Wrap(err)
+	}
+
+	deliver := func(block sdk.FollowedBlock) error {
+		if err := handler(block); err != nil {
+			return handlerError{err: err}
+		}
+		next = block.Header.Height + 1
+		if opts.Checkpoint != nil {
+			if err := opts.Checkpoint.Save(opts.Name, block.Header.Height); err != nil {
+				return handlerError{err: err}
+			}
+		}
+		return nil
+	}
+
+	// the new block events only wake up the follower, the subscription ends with the follower
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var live *sdk.EventSubscription
+
+	for {
+		latest, err := base.latestHeight(ctx)
+		if err == nil && next <= latest {
+			err = base.fetchBlocks(ctx, next, latest, opts.Concurrency, deliver)
+			if err == nil {
+				continue
+			}
+		}
+		if err != nil {
+			var herr handlerError
+			if errors.As(err, &herr) {
+				return sdk.Wrap(herr.err)
+			}
+			if ctx.Err() != nil {
+				return sdk.Wrap(ctx.Err())
+			}
+			base.Logger().Error("follow blocks failed", "height", next, "errMsg", err.Error())
+			if !sleepContext(ctx, followRetryInterval) {
+				return sdk.Wrap(ctx.Err())
+			}
+			continue
+		}
+
+		if !base.waitNewBlock(ctx, &live) {
+			return sdk.Wrap(ctx.Err())
+		}
+	}
+}
+
+// followStart returns the first height to follow
+func (base baseClient) followStart(ctx context.Context, opts sdk.FollowOptions) (int64, error) {
+	if opts.Checkpoint != nil {
+		height, ok, err := opts.Checkpoint.Load(opts.Name)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return height + 1, nil
+		}
+	}
+	if opts.StartHeight > 0 {
+		return opts.StartHeight, nil
+	}
+	return base.latestHeight(ctx)
+}
+
+func (base baseClient) latestHeight(ctx context.Context) (int64, error) {
+	status, err := base.Status(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// waitNewBlock waits for the next new block event, the subscription is made again when it ended,
+// false is returned when ctx is done
+func (base baseClient) waitNewBlock(ctx context.Context, live **sdk.EventSubscription) bool {
+	if *live == nil {
+		query := tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
+		// only the latest event matters, the heights are fetched in order anyway
+		sub, err := base.SubscribeChan(ctx, query, sdk.SubscribeOptions{BufferSize: 1, Overflow: sdk.OverflowDropOldest})
+		if err != nil {
+			base.Logger().Debug("subscribe new block failed, polling the latest height", "errMsg", err.Error())
+			return sleepContext(ctx, followPollInterval)
+		}
+		*live = &sub
+	}
+
+	timer := time.NewTimer(followLiveTimeout)
+	defer timer.Stop()
+	select {
+	case _, ok := <-(*live).Events:
+		if !ok {
+			*live = nil
+		}
+		return true
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// fetchBlocks fetches the blocks from..to with at most concurrency requests in flight,
+// and delivers them in the order of their heights
+func (base baseClient) fetchBlocks(ctx context.Context, from, to int64, concurrency int,
+	deliver func(block sdk.FollowedBlock) error) error {
+	type result struct {
+		block sdk.FollowedBlock
+		err   error
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// the results are queued in the order of the heights, the request being delivered
+	// and the queued ones are the requests in flight
+	results := make(chan chan result, concurrency-1)
+	go func() {
+		defer close(results)
+		for height := from; height <= to; height++ {
+			res := make(chan result, 1)
+			select {
+			case results <- res:
+			case <-ctx.Done():
+				return
+			}
+			go func(height int64) {
+				block, err := base.fetchBlock(ctx, height)
+				res <- result{block: block, err: err}
+			}(height)
+		}
+	}()
+
+	for res := range results {
+		r := <-res
+		if r.err != nil {
+			return r.err
+		}
+		if err := deliver(r.block); err != nil {
+			return err
+		}
+	}
+	return ctx.Err()
+}
+
+func (base baseClient) fetchBlock(ctx context.Context, height int64) (sdk.FollowedBlock, error) {
+	block, err := base.Block(ctx, &height)
+	if err != nil {
+		return sdk.FollowedBlock{}, err
+	}
+	blockResults, err := base.BlockResults(ctx, &height)
+	if err != nil {
+		return sdk.FollowedBlock{}, err
+	}
+	if len(blockResults.TxsResults) != len(block.Block.Txs) {
+		return sdk.FollowedBlock{}, sdk.Wrapf("block %d has %d txs but %d results",
+			height, len(block.Block.Txs), len(blockResults.TxsResults))
+	}
+
+	results := sdk.ParseBlockResult(blockResults)
+	txDecoder := base.encodingConfig.TxConfig.TxDecoder()
+	txs := make([]sdk.BlockTx, len(block.Block.Txs))
+	for i, bz := range block.Block.Txs {
+		tx, err := txDecoder(bz)
+		if err != nil {
+			base.Logger().Debug("decode tx failed", "height", height, "index", i, "errMsg", err.Error())
+			tx = nil
+		}
+		result := results.Results.DeliverTx[i]
+		result.Codespace = blockResults.TxsResults[i].Codespace
+		txs[i] = sdk.BlockTx{
+			Hash:   sdk.HexBytes(bz.Hash()).String(),
+			Index:  uint32(i),
+			Bytes:  bz,
+			Tx:     tx,
+			Result: result,
+		}
+	}
+	return sdk.FollowedBlock{
+		BlockID:    block.BlockID,
+		Header:     block.Block.Header,
+		Txs:        txs,
+		BeginBlock: results.Results.BeginBlock,
+		EndBlock:   results.Results.EndBlock,
+	}, nil
+}
+
+// sleepContext waits for d, false is returned if ctx is done before
+func sleepContext(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
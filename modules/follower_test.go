@@ -0,0 +1,103 @@
+package modules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"plugchain-sdk-go/codec"
+	cdctypes "plugchain-sdk-go/codec/types"
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/store"
+	txtypes "plugchain-sdk-go/types/tx"
+	"plugchain-sdk-go/utils/rpctest"
+)
+
+func TestFollowBlocks(t *testing.T) {
+	txConfig := txtypes.NewTxConfig(codec.NewProtoCodec(cdctypes.NewInterfaceRegistry()), txtypes.DefaultSignModes)
+	builder := txConfig.NewTxBuilder()
+	builder.SetMemo("followed")
+	txBytes, err := txConfig.TxEncoder()(builder.GetTx())
+	require.NoError(t, err)
+
+	// every block has a tx and a tx that can not be decoded
+	var latest int64 = 5
+	paramHeight := func(req rpctypes.RPCRequest) int64 {
+		var params struct {
+			Height string `json:"height"`
+		}
+		_ = json.Unmarshal(req.Params, &params)
+		height, _ := strconv.ParseInt(params.Height, 10, 64)
+		return height
+	}
+	node := rpctest.NewNode(t, map[string]func(rpctypes.RPCRequest) rpctypes.RPCResponse{
+		"status": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			return rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultStatus{
+				NodeInfo: p2p.DefaultNodeInfo{Network: "chaintest-1"},
+				SyncInfo: ctypes.SyncInfo{LatestBlockHeight: atomic.LoadInt64(&latest)},
+			})
+		},
+		"block": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			return rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultBlock{
+				Block: &tmtypes.Block{
+					Header: tmtypes.Header{Height: paramHeight(req)},
+					Data:   tmtypes.Data{Txs: tmtypes.Txs{txBytes, []byte("invalid")}},
+				},
+			})
+		},
+		"block_results": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			return rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultBlockResults{
+				Height:     paramHeight(req),
+				TxsResults: []*abci.ResponseDeliverTx{{}, {Code: 5, Codespace: "sdk"}},
+			})
+		},
+	})
+
+	pool, err := NewRPCPool([]string{node.URL}, "chaintest-1", nil, nil, log.NewNopLogger(), 1, 0)
+	require.NoError(t, err)
+	defer pool.Stop()
+	base := baseClient{TmClient: pool, logger: log.NewNopLogger(), encodingConfig: sdk.EncodingConfig{TxConfig: txConfig}}
+
+	checkpoint, err := store.NewLevelDBCheckpoint(t.TempDir())
+	require.NoError(t, err)
+	defer checkpoint.Close()
+	require.NoError(t, checkpoint.Save("test", 2))
+
+	// the past blocks are delivered in order from the checkpoint, then the new blocks
+	var heights []int64
+	errStop := errors.New("stop")
+	e := base.FollowBlocks(context.Background(), sdk.FollowOptions{Name: "test", Checkpoint: checkpoint, Concurrency: 2},
+		func(block sdk.FollowedBlock) error {
+			heights = append(heights, block.Header.Height)
+			require.Len(t, block.Txs, 2)
+			require.Equal(t, "followed", block.Txs[0].Tx.(sdk.TxWithMemo).GetMemo())
+			require.Nil(t, block.Txs[1].Tx)
+			require.Equal(t, "sdk", block.Txs[1].Result.Codespace)
+			if block.Header.Height == 5 {
+				atomic.StoreInt64(&latest, 7)
+			}
+			if block.Header.Height == 7 {
+				return errStop
+			}
+			return nil
+		})
+	require.True(t, errors.Is(e, errStop))
+	require.Equal(t, []int64{3, 4, 5, 6, 7}, heights)
+
+	// the block of the failed handler is delivered again when the follower resumes
+	height, ok, err := checkpoint.Load("test")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, int64(6), height)
+}
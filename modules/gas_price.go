@@ -0,0 +1,71 @@
+package modules
+
+import (
+	"regexp"
+	"sync"
+
+	clienttx "plugchain-sdk-go/client/tx"
+	sdk "plugchain-sdk-go/types"
+)
+
+// requiredFeesRegexp matches the fees required by the node in an insufficient fee error,
+// e.g. "insufficient fees; got: 100plug required: 200plug"
+var requiredFeesRegexp = regexp.MustCompile(`insufficient fees; got: \S* required: ([^\s:]+)`)
+
+// minGasPrices remembers the minimum gas prices reported by the node, so that
+// a tx paying less is rejected before it is broadcast
+type minGasPrices struct {
+	mtx    sync.RWMutex
+	prices sdk.DecCoins
+}
+
+// learn extracts the minimum gas prices from an insufficient fee error of a tx with the given gas
+func (m *minGasPrices) learn(err error, gas uint64) {
+	if err == nil || gas == 0 {
+		return
+	}
+
+	matches := requiredFeesRegexp.FindStringSubmatch(err.Error())
+	if len(matches) != 2 {
+		return
+	}
+
+	required, e := sdk.ParseDecCoins(matches[1])
+	if e != nil || required.Empty() {
+		return
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.prices = required.QuoDec(sdk.NewDec(int64(gas)))
+}
+
+// check returns an error if the fees of the builder are below the known minimum gas prices,
+// like the node, the fees only need to cover one of the denoms
+func (m *minGasPrices) check(builder *clienttx.Factory) sdk.Error {
+	m.mtx.RLock()
+	prices := m.prices
+	m.mtx.RUnlock()
+
+	if prices.Empty() {
+		return nil
+	}
+
+	fees, err := builder.ComputeFees()
+	if err != nil {
+		return sdk.Wrap(err)
+	}
+
+	gas := sdk.NewDec(int64(builder.Gas()))
+	required := make(sdk.Coins, len(prices))
+	for i, price := range prices {
+		amount := price.Amount.Mul(gas).Ceil().RoundInt()
+		if fees.AmountOf(price.Denom).GTE(amount) {
+			return nil
+		}
+		required[i] = sdk.NewCoin(price.Denom, amount)
+	}
+
+	return sdk.WrapErrorf(sdk.ErrInsufficientFee, "insufficient fees; got: %s required: %s, below the minimum gas prices %s of the node",
+		fees, required, prices)
+}
@@ -0,0 +1,33 @@
+package modules
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	clienttx "plugchain-sdk-go/client/tx"
+	sdk "plugchain-sdk-go/types"
+)
+
+func TestMinGasPrices(t *testing.T) {
+	prices, err := sdk.ParseDecCoins("0.5uplug")
+	require.NoError(t, err)
+
+	builder := clienttx.NewFactory().WithGas(100001).WithGasPrices(prices)
+	fees, err := builder.ComputeFees()
+	require.NoError(t, err)
+	require.Equal(t, "50001uplug", fees.String())
+
+	var m minGasPrices
+	require.Nil(t, m.check(builder))
+
+	m.learn(errors.New("insufficient fees; got: 100uplug required: 200000uplug: insufficient fee"), 200000)
+	require.Equal(t, "1.000000000000000000uplug", m.prices.String())
+	checkErr := m.check(builder)
+	require.True(t, errors.Is(checkErr, sdk.ErrInsufficientFee))
+	require.Equal(t, uint32(sdk.InsufficientFee), checkErr.Code())
+
+	builder.WithGasPrices(nil).WithFee(sdk.NewCoins(sdk.NewCoin("uplug", sdk.NewInt(100001))))
+	require.Nil(t, m.check(builder))
+}
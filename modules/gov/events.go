@@ -0,0 +1,34 @@
+package gov
+
+import (
+	sdk "plugchain-sdk-go/types"
+)
+
+// SubmitProposalEvent is emitted when a proposal is submitted
+type SubmitProposalEvent struct {
+	ProposalID uint64 `event:"proposal_id"`
+}
+
+func (SubmitProposalEvent) EventType() string { return sdk.EventTypeSubmitProposal }
+
+// ProposalDepositEvent is emitted for every deposit on a proposal
+type ProposalDepositEvent struct {
+	ProposalID uint64    `event:"proposal_id"`
+	Amount     sdk.Coins `event:"amount"`
+}
+
+func (ProposalDepositEvent) EventType() string { return "proposal_deposit" }
+
+// ProposalVoteEvent is emitted for every vote on a proposal
+type ProposalVoteEvent struct {
+	ProposalID uint64 `event:"proposal_id"`
+	Option     string `event:"option"`
+}
+
+func (ProposalVoteEvent) EventType() string { return "proposal_vote" }
+
+func init() {
+	sdk.RegisterEvent(SubmitProposalEvent{})
+	sdk.RegisterEvent(ProposalDepositEvent{})
+	sdk.RegisterEvent(ProposalVoteEvent{})
+}
@@ -1,6 +1,8 @@
 package gov
 
 import (
+	"context"
+
 	"time"
 
 	sdk "plugchain-sdk-go/types"
@@ -13,6 +15,11 @@ type Client interface {
 	Deposit(request DepositRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 	Vote(request VoteRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 
+	// the msgs of the above methods, to be sent together with the msgs of other modules
+	SubmitProposalMsg(proposer string, request SubmitProposalRequest) (sdk.Msg, sdk.Error)
+	DepositMsg(depositor string, request DepositRequest) (sdk.Msg, sdk.Error)
+	VoteMsg(voter string, request VoteRequest) (sdk.Msg, sdk.Error)
+
 	QueryProposal(proposalId uint64) (QueryProposalResp, sdk.Error)
 	QueryProposals(proposalStatus string) ([]QueryProposalResp, sdk.Error)
 	QueryVote(proposalId uint64, voter string) (QueryVoteResp, sdk.Error)
@@ -21,6 +28,25 @@ type Client interface {
 	QueryDeposit(proposalId uint64, depositor string) (QueryDepositResp, sdk.Error)
 	QueryDeposits(proposalId uint64) ([]QueryDepositResp, sdk.Error)
 	QueryTallyResult(proposalId uint64) (QueryTallyResultResp, sdk.Error)
+
+	SubmitProposalContext(ctx context.Context, request SubmitProposalRequest, baseTx sdk.BaseTx) (uint64, sdk.ResultTx, sdk.Error)
+	DepositContext(ctx context.Context, request DepositRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	VoteContext(ctx context.Context, request VoteRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	SubmitProposalMsgContext(ctx context.Context, proposer string, request SubmitProposalRequest) (sdk.Msg, sdk.Error)
+	DepositMsgContext(ctx context.Context, depositor string, request DepositRequest) (sdk.Msg, sdk.Error)
+	QueryProposalContext(ctx context.Context, proposalId uint64) (QueryProposalResp, sdk.Error)
+	QueryProposalsContext(ctx context.Context, proposalStatus string) ([]QueryProposalResp, sdk.Error)
+	QueryVoteContext(ctx context.Context, proposalId uint64, voter string) (QueryVoteResp, sdk.Error)
+	QueryVotesContext(ctx context.Context, proposalId uint64) ([]QueryVoteResp, sdk.Error)
+	QueryParamsContext(ctx context.Context, paramsType string) (QueryParamsResp, sdk.Error)
+	QueryDepositContext(ctx context.Context, proposalId uint64, depositor string) (QueryDepositResp, sdk.Error)
+	QueryDepositsContext(ctx context.Context, proposalId uint64) ([]QueryDepositResp, sdk.Error)
+	QueryTallyResultContext(ctx context.Context, proposalId uint64) (QueryTallyResultResp, sdk.Error)
+
+	// the list queries walking every page, see sdk.PageIterator
+	IterateProposals(ctx context.Context, proposalStatus string, size uint64) ProposalIterator
+	IterateVotes(ctx context.Context, proposalId uint64, size uint64) VoteIterator
+	IterateDeposits(ctx context.Context, proposalId uint64, size uint64) DepositIterator
 }
 
 type SubmitProposalRequest struct {
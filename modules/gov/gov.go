@@ -7,9 +7,11 @@ import (
 	"plugchain-sdk-go/codec"
 	"plugchain-sdk-go/codec/types"
 	sdk "plugchain-sdk-go/types"
-	"plugchain-sdk-go/types/query"
 )
 
+// listPageSize is the size of the pages fetched by the queries returning all the items of a list
+const listPageSize = 100
+
 type govClient struct {
 	sdk.BaseClient
 	codec.Marshaler
@@ -30,24 +32,26 @@ func (gc govClient) RegisterInterfaceTypes(registry types.InterfaceRegistry) {
 	RegisterInterfaces(registry)
 }
 
+func (gc govClient) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	RegisterLegacyAminoCodec(cdc)
+}
+
 func (gc govClient) SubmitProposal(request SubmitProposalRequest, baseTx sdk.BaseTx) (uint64, sdk.ResultTx, sdk.Error) {
+	return gc.SubmitProposalContext(context.Background(), request, baseTx)
+}
+
+func (gc govClient) SubmitProposalContext(ctx context.Context, request SubmitProposalRequest, baseTx sdk.BaseTx) (uint64, sdk.ResultTx, sdk.Error) {
 	proposer, err := gc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return 0, sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	deposit, err := gc.ToMinCoin(request.InitialDeposit...)
+	msg, err := gc.SubmitProposalMsgContext(ctx, proposer.String(), request)
 	if err != nil {
-		return 0, sdk.ResultTx{}, sdk.Wrap(err)
+		return 0, sdk.ResultTx{}, err
 	}
 
-	content := ContentFromProposalType(request.Title, request.Description, request.Type)
-	msg, e := NewMsgSubmitProposal(content, deposit, proposer)
-	if e != nil {
-		return 0, sdk.ResultTx{}, sdk.Wrap(e)
-	}
-
-	result, err := gc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	result, err := gc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
 	if err != nil {
 		return 0, sdk.ResultTx{}, sdk.Wrap(err)
 	}
@@ -64,50 +68,117 @@ func (gc govClient) SubmitProposal(request SubmitProposalRequest, baseTx sdk.Bas
 	return uint64(proposalId), result, err
 }
 
+// SubmitProposalMsg returns the msg of SubmitProposal for the account `proposer`
+func (gc govClient) SubmitProposalMsg(proposer string, request SubmitProposalRequest) (sdk.Msg, sdk.Error) {
+	return gc.SubmitProposalMsgContext(context.Background(), proposer, request)
+}
+
+func (gc govClient) SubmitProposalMsgContext(ctx context.Context, proposer string, request SubmitProposalRequest) (sdk.Msg, sdk.Error) {
+	proposerAddr, err := sdk.AccAddressFromBech32(proposer)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	deposit, err := gc.ToMinCoinContext(ctx, request.InitialDeposit...)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	content := ContentFromProposalType(request.Title, request.Description, request.Type)
+	msg, e := NewMsgSubmitProposal(content, deposit, proposerAddr)
+	if e != nil {
+		return nil, sdk.Wrap(e)
+	}
+	return msg, nil
+}
+
 func (gc govClient) Deposit(request DepositRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return gc.DepositContext(context.Background(), request, baseTx)
+}
+
+func (gc govClient) DepositContext(ctx context.Context, request DepositRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	depositor, err := gc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	amount, err := gc.ToMinCoin(request.Amount...)
+	msg, err := gc.DepositMsgContext(ctx, depositor.String(), request)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return sdk.ResultTx{}, err
+	}
+	return gc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// DepositMsg returns the msg of Deposit for the account `depositor`
+func (gc govClient) DepositMsg(depositor string, request DepositRequest) (sdk.Msg, sdk.Error) {
+	return gc.DepositMsgContext(context.Background(), depositor, request)
+}
+
+func (gc govClient) DepositMsgContext(ctx context.Context, depositor string, request DepositRequest) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(depositor); err != nil {
+		return nil, sdk.Wrap(err)
 	}
 
-	msg := &MsgDeposit{
+	amount, err := gc.ToMinCoinContext(ctx, request.Amount...)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	return &MsgDeposit{
 		ProposalId: request.ProposalId,
-		Depositor:  depositor.String(),
+		Depositor:  depositor,
 		Amount:     amount,
-	}
-	return gc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 // about VoteRequest.Option see  VoteOption_value
 func (gc govClient) Vote(request VoteRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return gc.VoteContext(context.Background(), request, baseTx)
+}
+
+func (gc govClient) VoteContext(ctx context.Context, request VoteRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	voter, err := gc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	option := VoteOption_value[request.Option]
-	msg := &MsgVote{
+	msg, err := gc.VoteMsg(voter.String(), request)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return gc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// VoteMsg returns the msg of Vote for the account `voter`
+func (gc govClient) VoteMsg(voter string, request VoteRequest) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(voter); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	option, ok := VoteOption_value[request.Option]
+	if !ok {
+		return nil, sdk.Wrapf("invalid vote option %s", request.Option)
+	}
+
+	return &MsgVote{
 		ProposalId: request.ProposalId,
-		Voter:      voter.String(),
+		Voter:      voter,
 		Option:     VoteOption(option),
-	}
-	return gc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 func (gc govClient) QueryProposal(proposalId uint64) (QueryProposalResp, sdk.Error) {
+	return gc.QueryProposalContext(context.Background(), proposalId)
+}
+
+func (gc govClient) QueryProposalContext(ctx context.Context, proposalId uint64) (QueryProposalResp, sdk.Error) {
 	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryProposalResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Proposal(
-		context.Background(),
+		ctx,
 		&QueryProposalRequest{
 			ProposalId: proposalId,
 		})
@@ -117,41 +188,29 @@ func (gc govClient) QueryProposal(proposalId uint64) (QueryProposalResp, sdk.Err
 	return res.Proposal.Convert().(QueryProposalResp), nil
 }
 
-// if proposalStatus is nil will return all status's proposals
-// about proposalStatus see VoteOption_value
+// if proposalStatus is empty will return all status's proposals
+// about proposalStatus see ProposalStatus_value
 func (gc govClient) QueryProposals(proposalStatus string) ([]QueryProposalResp, sdk.Error) {
-	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
+	return gc.QueryProposalsContext(context.Background(), proposalStatus)
+}
 
-	res, err := NewQueryClient(conn).Proposals(
-		context.Background(),
-		&QueryProposalsRequest{
-			ProposalStatus: ProposalStatus(VoteOption_value[proposalStatus]),
-			Pagination: &query.PageRequest{
-				Offset:     0,
-				Limit:      100,
-				CountTotal: true,
-			},
-		})
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return Proposals(res.Proposals).Convert().([]QueryProposalResp), nil
+func (gc govClient) QueryProposalsContext(ctx context.Context, proposalStatus string) ([]QueryProposalResp, sdk.Error) {
+	return gc.IterateProposals(ctx, proposalStatus, listPageSize).All()
 }
 
 // about QueryVoteResp.Option see VoteOption_name
 func (gc govClient) QueryVote(proposalId uint64, voter string) (QueryVoteResp, sdk.Error) {
+	return gc.QueryVoteContext(context.Background(), proposalId, voter)
+}
+
+func (gc govClient) QueryVoteContext(ctx context.Context, proposalId uint64, voter string) (QueryVoteResp, sdk.Error) {
 	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryVoteResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Vote(
-		context.Background(),
+		ctx,
 		&QueryVoteRequest{
 			ProposalId: proposalId,
 			Voter:      voter,
@@ -163,38 +222,26 @@ func (gc govClient) QueryVote(proposalId uint64, voter string) (QueryVoteResp, s
 }
 
 func (gc govClient) QueryVotes(proposalId uint64) ([]QueryVoteResp, sdk.Error) {
-	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
+	return gc.QueryVotesContext(context.Background(), proposalId)
+}
 
-	res, err := NewQueryClient(conn).Votes(
-		context.Background(),
-		&QueryVotesRequest{
-			ProposalId: proposalId,
-			Pagination: &query.PageRequest{
-				Offset:     0,
-				Limit:      100,
-				CountTotal: true,
-			},
-		})
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return Votes(res.Votes).Convert().([]QueryVoteResp), nil
+func (gc govClient) QueryVotesContext(ctx context.Context, proposalId uint64) ([]QueryVoteResp, sdk.Error) {
+	return gc.IterateVotes(ctx, proposalId, listPageSize).All()
 }
 
 // QueryParams params_type("voting", "tallying", "deposit"), if don't pass will return all params_typ res
 func (gc govClient) QueryParams(paramsType string) (QueryParamsResp, sdk.Error) {
+	return gc.QueryParamsContext(context.Background(), paramsType)
+}
+
+func (gc govClient) QueryParamsContext(ctx context.Context, paramsType string) (QueryParamsResp, sdk.Error) {
 	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryParamsResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Params(
-		context.Background(),
+		ctx,
 		&QueryParamsRequest{
 			ParamsType: paramsType,
 		},
@@ -206,14 +253,17 @@ func (gc govClient) QueryParams(paramsType string) (QueryParamsResp, sdk.Error)
 }
 
 func (gc govClient) QueryDeposit(proposalId uint64, depositor string) (QueryDepositResp, sdk.Error) {
+	return gc.QueryDepositContext(context.Background(), proposalId, depositor)
+}
+
+func (gc govClient) QueryDepositContext(ctx context.Context, proposalId uint64, depositor string) (QueryDepositResp, sdk.Error) {
 	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryDepositResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Deposit(
-		context.Background(),
+		ctx,
 		&QueryDepositRequest{
 			ProposalId: proposalId,
 			Depositor:  depositor,
@@ -226,38 +276,25 @@ func (gc govClient) QueryDeposit(proposalId uint64, depositor string) (QueryDepo
 }
 
 func (gc govClient) QueryDeposits(proposalId uint64) ([]QueryDepositResp, sdk.Error) {
-	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
+	return gc.QueryDepositsContext(context.Background(), proposalId)
+}
 
-	res, err := NewQueryClient(conn).Deposits(
-		context.Background(),
-		&QueryDepositsRequest{
-			ProposalId: proposalId,
-			Pagination: &query.PageRequest{
-				Offset:     0,
-				Limit:      100,
-				CountTotal: true,
-			},
-		},
-	)
-	if err != nil {
-		return nil, sdk.Wrap(err)
-	}
-	return Deposits(res.Deposits).Convert().([]QueryDepositResp), nil
+func (gc govClient) QueryDepositsContext(ctx context.Context, proposalId uint64) ([]QueryDepositResp, sdk.Error) {
+	return gc.IterateDeposits(ctx, proposalId, listPageSize).All()
 }
 
 func (gc govClient) QueryTallyResult(proposalId uint64) (QueryTallyResultResp, sdk.Error) {
+	return gc.QueryTallyResultContext(context.Background(), proposalId)
+}
+
+func (gc govClient) QueryTallyResultContext(ctx context.Context, proposalId uint64) (QueryTallyResultResp, sdk.Error) {
 	conn, err := gc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryTallyResultResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).TallyResult(
-		context.Background(),
+		ctx,
 		&QueryTallyResultRequest{
 			ProposalId: proposalId,
 		},
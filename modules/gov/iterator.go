@@ -0,0 +1,128 @@
+package gov
+
+import (
+	"context"
+
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/query"
+)
+
+// ProposalIterator walks the proposals of every page
+type ProposalIterator struct {
+	*sdk.PageIterator
+}
+
+func (it ProposalIterator) Proposal() QueryProposalResp {
+	return it.Item().(QueryProposalResp)
+}
+
+// All returns the proposals of the pages left
+func (it ProposalIterator) All() ([]QueryProposalResp, sdk.Error) {
+	var proposals []QueryProposalResp
+	for it.Next() {
+		proposals = append(proposals, it.Proposal())
+	}
+	return proposals, it.Err()
+}
+
+// VoteIterator walks the votes of every page
+type VoteIterator struct {
+	*sdk.PageIterator
+}
+
+func (it VoteIterator) Vote() QueryVoteResp {
+	return it.Item().(QueryVoteResp)
+}
+
+// All returns the votes of the pages left
+func (it VoteIterator) All() ([]QueryVoteResp, sdk.Error) {
+	var votes []QueryVoteResp
+	for it.Next() {
+		votes = append(votes, it.Vote())
+	}
+	return votes, it.Err()
+}
+
+// DepositIterator walks the deposits of every page
+type DepositIterator struct {
+	*sdk.PageIterator
+}
+
+func (it DepositIterator) Deposit() QueryDepositResp {
+	return it.Item().(QueryDepositResp)
+}
+
+// All returns the deposits of the pages left
+func (it DepositIterator) All() ([]QueryDepositResp, sdk.Error) {
+	var deposits []QueryDepositResp
+	for it.Next() {
+		deposits = append(deposits, it.Deposit())
+	}
+	return deposits, it.Err()
+}
+
+// IterateProposals walks the proposals by pages of size, about proposalStatus see QueryProposals
+func (gc govClient) IterateProposals(ctx context.Context, proposalStatus string, size uint64) ProposalIterator {
+	return ProposalIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		status, ok := ProposalStatus_value[proposalStatus]
+		if !ok && len(proposalStatus) > 0 {
+			return nil, nil, sdk.Wrapf("invalid proposal status %s", proposalStatus)
+		}
+
+		conn, err := gc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).Proposals(ctx, &QueryProposalsRequest{
+			ProposalStatus: ProposalStatus(status),
+			Pagination:     page,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Proposals))
+		for i, p := range res.Proposals {
+			items[i] = p.Convert()
+		}
+		return items, res.Pagination, nil
+	})}
+}
+
+func (gc govClient) IterateVotes(ctx context.Context, proposalId uint64, size uint64) VoteIterator {
+	return VoteIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := gc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).Votes(ctx, &QueryVotesRequest{ProposalId: proposalId, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Votes))
+		for i, v := range res.Votes {
+			items[i] = v.Convert()
+		}
+		return items, res.Pagination, nil
+	})}
+}
+
+func (gc govClient) IterateDeposits(ctx context.Context, proposalId uint64, size uint64) DepositIterator {
+	return DepositIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := gc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).Deposits(ctx, &QueryDepositsRequest{ProposalId: proposalId, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Deposits))
+		for i, d := range res.Deposits {
+			items[i] = d.Convert()
+		}
+		return items, res.Pagination, nil
+	})}
+}
@@ -1,15 +1,222 @@
 package modules
 
-import "google.golang.org/grpc"
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"sync"
+	"sync/atomic"
 
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/resolver"
+	"google.golang.org/grpc/resolver/manual"
+
+	sdk "plugchain-sdk-go/types"
+)
+
+const grpcResolverScheme = "plugchain"
+
+// grpcServiceConfig balances the calls over all the ready addresses and retries
+// a call on another address if the node is unavailable
+const grpcServiceConfig = `{
+	"loadBalancingConfig": [{"round_robin": {}}],
+	"methodConfig": [{
+		"name": [{}],
+		"retryPolicy": {
+			"maxAttempts": 3,
+			"initialBackoff": "0.1s",
+			"maxBackoff": "1s",
+			"backoffMultiplier": 2,
+			"retryableStatusCodes": ["UNAVAILABLE"]
+		}
+	}]
+}`
+
+var errGRPCClientClosed = errors.New("grpc client is closed")
+
+// grpcClient keeps a small pool of long-lived connections, the connections are
+// dialed on first use and shared by all the queries. When several addresses are
+// given, every connection balances over the healthy ones.
 type grpcClient struct {
-	url string
+	addrs     []string
+	active    []string
+	size      int
+	opts      []grpc.DialOption
+	next      uint32
+	mtx       sync.Mutex
+	conns     []*grpc.ClientConn
+	resolvers []*manual.Resolver
+	close     bool
 }
 
-func NewGRPCClient(url string) grpcClient {
-	return grpcClient{url: url}
+func NewGRPCClient(url string, size int, opts ...grpc.DialOption) *grpcClient {
+	return NewGRPCClientWithAddrs([]string{url}, size, opts...)
 }
 
-func (g grpcClient) GenConn() (*grpc.ClientConn, error) {
-	return grpc.Dial(g.url, grpc.WithInsecure())
+// NewGRPCClientWithAddrs creates a client balancing over several addresses of the same chain
+func NewGRPCClientWithAddrs(addrs []string, size int, opts ...grpc.DialOption) *grpcClient {
+	if size <= 0 {
+		size = 1
+	}
+	if len(opts) == 0 {
+		opts = []grpc.DialOption{grpc.WithInsecure()}
+	}
+	return &grpcClient{
+		addrs:  addrs,
+		active: addrs,
+		size:   size,
+		opts:   opts,
+	}
+}
+
+// GenConn returns one of the pooled connections, the caller must not close it
+func (g *grpcClient) GenConn() (*grpc.ClientConn, error) {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	if g.close {
+		return nil, errGRPCClientClosed
+	}
+
+	if len(g.conns) == 0 {
+		for i := 0; i < g.size; i++ {
+			conn, err := g.dial()
+			if err != nil {
+				g.closeConns()
+				return nil, err
+			}
+			g.conns = append(g.conns, conn)
+		}
+	}
+
+	i := atomic.AddUint32(&g.next, 1)
+	return g.conns[int(i)%len(g.conns)], nil
+}
+
+func (g *grpcClient) dial() (*grpc.ClientConn, error) {
+	if len(g.addrs) == 1 {
+		return grpc.Dial(g.addrs[0], g.opts...)
+	}
+
+	r := manual.NewBuilderWithScheme(grpcResolverScheme)
+	r.InitialState(g.resolverState())
+	opts := append([]grpc.DialOption{
+		grpc.WithResolvers(r),
+		grpc.WithDefaultServiceConfig(grpcServiceConfig),
+	}, g.opts...)
+
+	conn, err := grpc.Dial(r.Scheme()+":///"+g.addrs[0], opts...)
+	if err != nil {
+		return nil, err
+	}
+	g.resolvers = append(g.resolvers, r)
+	return conn, nil
+}
+
+// SetHealthy restricts the addresses used by the connections to the healthy ones,
+// healthy is indexed like the addresses, all the addresses are used if none is healthy
+func (g *grpcClient) SetHealthy(healthy []bool) {
+	if len(healthy) != len(g.addrs) {
+		return
+	}
+
+	var active []string
+	for i, addr := range g.addrs {
+		if healthy[i] {
+			active = append(active, addr)
+		}
+	}
+	if len(active) == 0 {
+		active = g.addrs
+	}
+
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.active = active
+	for _, r := range g.resolvers {
+		r.UpdateState(g.resolverState())
+	}
+}
+
+func (g *grpcClient) resolverState() resolver.State {
+	addrs := make([]resolver.Address, len(g.active))
+	for i, addr := range g.active {
+		// the host of every address is used as the tls server name
+		host, _, err := net.SplitHostPort(addr)
+		if err != nil {
+			host = addr
+		}
+		addrs[i] = resolver.Address{Addr: addr, ServerName: host}
+	}
+	return resolver.State{Addresses: addrs}
+}
+
+// Close closes all the pooled connections, GenConn fails after Close is called
+func (g *grpcClient) Close() error {
+	g.mtx.Lock()
+	defer g.mtx.Unlock()
+
+	g.close = true
+	return g.closeConns()
+}
+
+func (g *grpcClient) closeConns() (err error) {
+	for _, conn := range g.conns {
+		if e := conn.Close(); e != nil {
+			err = e
+		}
+	}
+	g.conns = nil
+	g.resolvers = nil
+	return err
+}
+
+// grpcDialOptions converts the grpc settings of ClientConfig to dial options
+func grpcDialOptions(cfg sdk.ClientConfig) []grpc.DialOption {
+	var opts []grpc.DialOption
+	if cfg.GRPCCredentials != nil {
+		opts = append(opts, grpc.WithTransportCredentials(cfg.GRPCCredentials))
+	} else {
+		opts = append(opts, grpc.WithInsecure())
+	}
+
+	if cfg.GRPCKeepalive != nil {
+		opts = append(opts, grpc.WithKeepaliveParams(*cfg.GRPCKeepalive))
+	}
+
+	if cfg.GRPCMaxMsgSize > 0 {
+		opts = append(opts, grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(cfg.GRPCMaxMsgSize),
+			grpc.MaxCallSendMsgSize(cfg.GRPCMaxMsgSize),
+		))
+	}
+	opts = append(opts, grpc.WithChainUnaryInterceptor(queryHeightInterceptor(cfg.QueryHeight)))
+	return append(opts, cfg.GRPCDialOptions...)
+}
+
+// queryHeightInterceptor pins the queries to the height of their context, or to defaultHeight, and
+// records the height returned by the node
+func queryHeightInterceptor(defaultHeight int64) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{},
+		cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		height, ok := sdk.HeightFromContext(ctx)
+		if !ok {
+			height = defaultHeight
+		}
+		if height > 0 {
+			ctx = metadata.AppendToOutgoingContext(ctx, sdk.GRPCBlockHeightHeader, strconv.FormatInt(height, 10))
+		}
+
+		var header metadata.MD
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Header(&header))...)
+		if values := header.Get(sdk.GRPCBlockHeightHeader); len(values) > 0 {
+			if height, e := strconv.ParseInt(values[0], 10, 64); e == nil {
+				sdk.RecordQueryHeight(ctx, height)
+			}
+		}
+		return err
+	}
 }
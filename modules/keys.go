@@ -19,7 +19,7 @@ type keyManager struct {
 func (k keyManager) Sign(name, password string, data []byte) ([]byte, tmcrypto.PubKey, error) {
 	info, err := k.keyDAO.Read(name, password)
 	if err != nil {
-		return nil, nil, fmt.Errorf("name %s not exist", name)
+		return nil, nil, types.WrapWithMessage(err, "read key %s failed", name)
 	}
 
 	km, err := crypto.NewPrivateKeyManager([]byte(info.PrivKeyArmor), string(info.Algo))
@@ -134,7 +134,7 @@ func (k keyManager) Import(name, password, armor string) (string, error) {
 func (k keyManager) Export(name, password string) (armor string, err error) {
 	info, err := k.keyDAO.Read(name, password)
 	if err != nil {
-		return armor, fmt.Errorf("name %s not exist", name)
+		return armor, types.WrapWithMessage(err, "read key %s failed", name)
 	}
 
 	km, err := crypto.NewPrivateKeyManager([]byte(info.PrivKeyArmor), info.Algo)
@@ -0,0 +1,144 @@
+package modules
+
+import (
+	"fmt"
+
+	kmultisig "plugchain-sdk-go/crypto/keys/multisig"
+	multisigtypes "plugchain-sdk-go/crypto/types/multisig"
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/tx"
+	"plugchain-sdk-go/types/tx/signing"
+)
+
+// multisigSignMode is the sign mode of the partial signatures: with SIGN_MODE_DIRECT the sign bytes
+// would include the bit array of the signers, which is unknown until all the signatures are collected
+const multisigSignMode = signing.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+
+// NewMultisigPubKey returns the k-of-n multisig key of pubKeys and its address.
+// Every party must use the keys in the same order, as the order changes the address.
+func (base *baseClient) NewMultisigPubKey(threshold int, pubKeys []sdk.TmPubKey) (sdk.TmPubKey, string, sdk.Error) {
+	if threshold <= 0 || threshold > len(pubKeys) {
+		return nil, "", sdk.Wrapf("invalid threshold %d for %d keys", threshold, len(pubKeys))
+	}
+
+	for i, pk := range pubKeys {
+		for _, other := range pubKeys[:i] {
+			if pk.Equals(other) {
+				return nil, "", sdk.Wrapf("duplicate key %X", pk.Bytes())
+			}
+		}
+	}
+
+	pubKey := kmultisig.NewLegacyAminoPubKey(threshold, pubKeys)
+	return pubKey, sdk.AccAddress(pubKey.Address()).String(), nil
+}
+
+// SignMultisigTx signs a tx built by BuildUnsignedTx for a multisig account with the key name
+// of the KeyDAO and returns the partial signature in JSON, to be merged by MultisignTx
+func (base *baseClient) SignMultisigTx(unsignedTx []byte, name, password string) ([]byte, sdk.Error) {
+	unsigned, txBuilder, e := base.decodeUnsignedTx(unsignedTx)
+	if e != nil {
+		return nil, e
+	}
+
+	signBytes, err := multisigSignBytes(unsigned, txBuilder.GetTx())
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	sigBytes, pubKey, err := base.KeyManager.Sign(name, password, signBytes)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	sig := signing.SignatureV2{
+		PubKey: pubKey,
+		Data: &signing.SingleSignatureData{
+			SignMode:  multisigSignMode,
+			Signature: sigBytes,
+		},
+		Sequence: unsigned.Sequence,
+	}
+	bz, err := base.encodingConfig.TxConfig.MarshalSignatureJSON([]signing.SignatureV2{sig})
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return bz, nil
+}
+
+// MultisignTx merges the partial signatures returned by SignMultisigTx into the signature of
+// the multisig key and returns the signed tx in JSON, which can be sent by BroadcastSignedTx.
+// An error is returned unless the signatures reach the threshold of the key.
+func (base *baseClient) MultisignTx(unsignedTx []byte, multisigPubKey sdk.TmPubKey, signatures ...[]byte) ([]byte, sdk.Error) {
+	pubKey, ok := multisigPubKey.(multisigtypes.PubKey)
+	if !ok {
+		return nil, sdk.Wrapf("%T is not a multisig key", multisigPubKey)
+	}
+
+	unsigned, txBuilder, e := base.decodeUnsignedTx(unsignedTx)
+	if e != nil {
+		return nil, e
+	}
+
+	signer := sdk.AccAddress(pubKey.Address())
+	for _, msg := range txBuilder.GetTx().GetMsgs() {
+		for _, addr := range msg.GetSigners() {
+			if !addr.Equals(signer) {
+				return nil, sdk.Wrapf("msg signer %s is not the multisig account %s", addr, signer)
+			}
+		}
+	}
+
+	txConfig := base.encodingConfig.TxConfig
+	multisigData := multisigtypes.NewMultisig(len(pubKey.GetPubKeys()))
+	for _, bz := range signatures {
+		sigs, err := txConfig.UnmarshalSignatureJSON(bz)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+
+		for _, sig := range sigs {
+			if sig.Sequence != unsigned.Sequence {
+				return nil, sdk.Wrapf("signature of sequence %d, expected %d", sig.Sequence, unsigned.Sequence)
+			}
+			if err := multisigtypes.AddSignatureV2(multisigData, sig, pubKey.GetPubKeys()); err != nil {
+				return nil, sdk.Wrap(err)
+			}
+		}
+	}
+
+	getSignBytes := func(mode signing.SignMode) ([]byte, error) {
+		if mode != multisigSignMode {
+			return nil, fmt.Errorf("unsupported sign mode %s", mode)
+		}
+		return multisigSignBytes(unsigned, txBuilder.GetTx())
+	}
+	if err := pubKey.VerifyMultisignature(getSignBytes, multisigData); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	sig := signing.SignatureV2{
+		PubKey:   pubKey,
+		Data:     multisigData,
+		Sequence: unsigned.Sequence,
+	}
+	if err := txBuilder.SetSignatures(sig); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	bz, err := txConfig.TxJSONEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return bz, nil
+}
+
+func multisigSignBytes(unsigned sdk.UnsignedTx, unsignedTx sdk.Tx) ([]byte, error) {
+	signerData := sdk.SignerData{
+		ChainID:       unsigned.ChainID,
+		AccountNumber: unsigned.AccountNumber,
+		Sequence:      unsigned.Sequence,
+	}
+	return tx.MakeSignModeHandler([]signing.SignMode{multisigSignMode}).
+		GetSignBytes(multisigSignMode, signerData, unsignedTx)
+}
@@ -13,10 +13,20 @@ var (
 )
 
 func init() {
+	RegisterLegacyAminoCodec(amino)
 	cryptocodec.RegisterCrypto(amino)
 	amino.Seal()
 }
 
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(&MsgIssueClass{}, "plugchain/nft/MsgIssueClass", nil)
+	cdc.RegisterConcrete(&MsgIssueNFT{}, "plugchain/nft/MsgIssueNFT", nil)
+	cdc.RegisterConcrete(&MsgEditNFT{}, "plugchain/nft/MsgEditNFT", nil)
+	cdc.RegisterConcrete(&MsgTransferNFT{}, "plugchain/nft/MsgTransferNFT", nil)
+	cdc.RegisterConcrete(&MsgBurnNFT{}, "plugchain/nft/MsgBurnNFT", nil)
+	cdc.RegisterConcrete(&MsgTransferClass{}, "plugchain/nft/MsgTransferClass", nil)
+}
+
 func RegisterInterfaces(registry types.InterfaceRegistry) {
 	registry.RegisterImplementations(
 		(*sdk.Msg)(nil),
@@ -0,0 +1,18 @@
+package nft
+
+import (
+	sdk "plugchain-sdk-go/types"
+)
+
+// the codes of the errors of the nft module returned by the node
+const (
+	codeUnknownClass uint32 = 3
+	codeUnknownNFT   uint32 = 6
+	codeUnauthorized uint32 = 8
+)
+
+func init() {
+	sdk.RegisterABCIError(ModuleName, codeUnknownClass, sdk.ErrNotFound)
+	sdk.RegisterABCIError(ModuleName, codeUnknownNFT, sdk.ErrNotFound)
+	sdk.RegisterABCIError(ModuleName, codeUnauthorized, sdk.ErrUnauthorized)
+}
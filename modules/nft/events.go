@@ -0,0 +1,70 @@
+package nft
+
+import (
+	sdk "plugchain-sdk-go/types"
+)
+
+// IssueClassEvent is emitted when a class of nfts is issued
+type IssueClassEvent struct {
+	ClassID string `event:"class_id"`
+	Owner   string `event:"owner"`
+}
+
+func (IssueClassEvent) EventType() string { return "issue_class" }
+
+// IssueNFTEvent is emitted when a nft is minted
+type IssueNFTEvent struct {
+	ID        string `event:"nft_id"`
+	ClassID   string `event:"class_id"`
+	URI       string `event:"uri"`
+	Recipient string `event:"recipient"`
+}
+
+func (IssueNFTEvent) EventType() string { return "issue_nft" }
+
+// EditNFTEvent is emitted when a nft is edited by its owner
+type EditNFTEvent struct {
+	ID      string `event:"nft_id"`
+	ClassID string `event:"class_id"`
+	URI     string `event:"uri"`
+	Owner   string `event:"owner"`
+}
+
+func (EditNFTEvent) EventType() string { return "edit_nft" }
+
+// TransferNFTEvent is emitted when a nft is transferred to a new owner
+type TransferNFTEvent struct {
+	ID        string `event:"nft_id"`
+	ClassID   string `event:"class_id"`
+	Owner     string `event:"owner"`
+	Recipient string `event:"recipient"`
+}
+
+func (TransferNFTEvent) EventType() string { return "transfer_nft" }
+
+// BurnNFTEvent is emitted when a nft is burnt
+type BurnNFTEvent struct {
+	ID      string `event:"nft_id"`
+	ClassID string `event:"class_id"`
+	Owner   string `event:"owner"`
+}
+
+func (BurnNFTEvent) EventType() string { return "burn_nft" }
+
+// TransferClassEvent is emitted when a class is transferred to a new owner
+type TransferClassEvent struct {
+	ClassID   string `event:"class_id"`
+	Owner     string `event:"owner"`
+	Recipient string `event:"recipient"`
+}
+
+func (TransferClassEvent) EventType() string { return "transfer_class" }
+
+func init() {
+	sdk.RegisterEvent(IssueClassEvent{})
+	sdk.RegisterEvent(IssueNFTEvent{})
+	sdk.RegisterEvent(EditNFTEvent{})
+	sdk.RegisterEvent(TransferNFTEvent{})
+	sdk.RegisterEvent(BurnNFTEvent{})
+	sdk.RegisterEvent(TransferClassEvent{})
+}
@@ -1,6 +1,10 @@
 package nft
 
-import sdk "plugchain-sdk-go/types"
+import (
+	"context"
+
+	sdk "plugchain-sdk-go/types"
+)
 
 // expose NFT module api for user
 type Client interface {
@@ -13,12 +17,38 @@ type Client interface {
 	TransferClass(request TransferClassRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 	BurnNFT(request BurnNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 
+	// the msgs of the above methods, to be sent together with the msgs of other modules
+	IssueDenomMsg(owner string, request IssueDenomRequest) (sdk.Msg, sdk.Error)
+	MintNFTMsg(owner string, request MintNFTRequest) (sdk.Msg, sdk.Error)
+	EditNFTMsg(owner string, request EditNFTRequest) (sdk.Msg, sdk.Error)
+	TransferNFTMsg(owner string, request TransferNFTRequest) (sdk.Msg, sdk.Error)
+	TransferClassMsg(owner string, request TransferClassRequest) (sdk.Msg, sdk.Error)
+	BurnNFTMsg(owner string, request BurnNFTRequest) (sdk.Msg, sdk.Error)
+
 	QuerySupply(denomID string) (uint64, sdk.Error)
 	QueryOwner(creator, classId string, pageReq sdk.PageRequest) (QueryOwnerResp, sdk.Error)
 	QueryCollection(denomID string, pageReq sdk.PageRequest) (QueryCollectionResp, sdk.Error)
 	QueryDenom(denomID string) (QueryDenomResp, sdk.Error)
 	QueryDenoms(pageReq sdk.PageRequest) ([]QueryDenomResp, sdk.Error)
 	QueryNFT(denomID, ID string) (QueryNFTResp, sdk.Error)
+
+	IssueDenomContext(ctx context.Context, request IssueDenomRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	MintNFTContext(ctx context.Context, request MintNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	EditNFTContext(ctx context.Context, request EditNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	TransferNFTContext(ctx context.Context, request TransferNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	TransferClassContext(ctx context.Context, request TransferClassRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	BurnNFTContext(ctx context.Context, request BurnNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	QuerySupplyContext(ctx context.Context, denomID string) (uint64, sdk.Error)
+	QueryOwnerContext(ctx context.Context, creator, classId string, pageReq sdk.PageRequest) (QueryOwnerResp, sdk.Error)
+	QueryCollectionContext(ctx context.Context, denomID string, pageReq sdk.PageRequest) (QueryCollectionResp, sdk.Error)
+	QueryDenomContext(ctx context.Context, denomID string) (QueryDenomResp, sdk.Error)
+	QueryDenomsContext(ctx context.Context, pageReq sdk.PageRequest) ([]QueryDenomResp, sdk.Error)
+	QueryNFTContext(ctx context.Context, denomID, ID string) (QueryNFTResp, sdk.Error)
+
+	// the list queries walking every page, see sdk.PageIterator
+	IterateDenoms(ctx context.Context, size uint64) DenomIterator
+	IterateCollection(ctx context.Context, denomID string, size uint64) NFTIterator
+	IterateOwner(ctx context.Context, creator, classId string, size uint64) OwnerIterator
 }
 
 type IssueDenomRequest struct {
@@ -0,0 +1,148 @@
+package nft
+
+import (
+	"context"
+
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/query"
+)
+
+// DenomIterator walks the denoms of every page
+type DenomIterator struct {
+	*sdk.PageIterator
+}
+
+func (it DenomIterator) Denom() QueryDenomResp {
+	return it.Item().(QueryDenomResp)
+}
+
+// All returns the denoms of the pages left
+func (it DenomIterator) All() ([]QueryDenomResp, sdk.Error) {
+	var denoms []QueryDenomResp
+	for it.Next() {
+		denoms = append(denoms, it.Denom())
+	}
+	return denoms, it.Err()
+}
+
+// NFTIterator walks the nfts of a collection of every page
+type NFTIterator struct {
+	*sdk.PageIterator
+}
+
+func (it NFTIterator) NFT() QueryNFTResp {
+	return it.Item().(QueryNFTResp)
+}
+
+// All returns the nfts of the pages left
+func (it NFTIterator) All() ([]QueryNFTResp, sdk.Error) {
+	var nfts []QueryNFTResp
+	for it.Next() {
+		nfts = append(nfts, it.NFT())
+	}
+	return nfts, it.Err()
+}
+
+// OwnerIterator walks the nfts of an owner of every page, the nfts of a denom may span several pages
+type OwnerIterator struct {
+	*sdk.PageIterator
+	address string
+}
+
+// IDC returns the nfts of a denom in the current page
+func (it OwnerIterator) IDC() IDC {
+	return it.Item().(IDC)
+}
+
+// All returns the nfts of the pages left, grouped by denom
+func (it OwnerIterator) All() (QueryOwnerResp, sdk.Error) {
+	owner := QueryOwnerResp{Address: it.address}
+	denoms := make(map[string]int)
+	for it.Next() {
+		idc := it.IDC()
+		if i, ok := denoms[idc.Denom]; ok {
+			owner.IDCs[i].TokenIDs = append(owner.IDCs[i].TokenIDs, idc.TokenIDs...)
+			continue
+		}
+		denoms[idc.Denom] = len(owner.IDCs)
+		owner.IDCs = append(owner.IDCs, idc)
+	}
+	return owner, it.Err()
+}
+
+// IterateDenoms walks the denoms by pages of size
+func (nc nftClient) IterateDenoms(ctx context.Context, size uint64) DenomIterator {
+	return DenomIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := nc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).Classes(ctx, &QueryClassesRequest{Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Classes))
+		for i, class := range res.Classes {
+			items[i] = class.Convert()
+		}
+		return items, res.Pagination, nil
+	})}
+}
+
+// IterateCollection walks the nfts of a denom by pages of size
+func (nc nftClient) IterateCollection(ctx context.Context, denom string, size uint64) NFTIterator {
+	return NFTIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		if len(denom) == 0 {
+			return nil, nil, sdk.Wrapf("denom is required")
+		}
+
+		conn, err := nc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).Collection(ctx, &QueryCollectionRequest{ClassId: denom, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		if res.Collection == nil {
+			return nil, res.Pagination, nil
+		}
+		items := make([]interface{}, len(res.Collection.NFTs))
+		for i, nft := range res.Collection.NFTs {
+			items[i] = nft.Convert()
+		}
+		return items, res.Pagination, nil
+	})}
+}
+
+// IterateOwner walks the nfts of the denom owned by creator by pages of size
+func (nc nftClient) IterateOwner(ctx context.Context, creator, denom string, size uint64) OwnerIterator {
+	return OwnerIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		if len(denom) == 0 {
+			return nil, nil, sdk.Wrapf("denom is required")
+		}
+		if err := sdk.ValidateAccAddress(creator); err != nil {
+			return nil, nil, err
+		}
+
+		conn, err := nc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).Owner(ctx, &QueryOwnerRequest{Address: creator, ClassId: denom, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		if res.Owner == nil {
+			return nil, res.Pagination, nil
+		}
+		items := make([]interface{}, len(res.Owner.CollectionIDs))
+		for i, idc := range res.Owner.CollectionIDs {
+			items[i] = IDC{Denom: idc.ClassID, TokenIDs: idc.NFTIDs}
+		}
+		return items, res.Pagination, nil
+	}), creator}
+}
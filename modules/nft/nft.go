@@ -29,131 +29,234 @@ func (nc nftClient) RegisterInterfaceTypes(registry types.InterfaceRegistry) {
 	RegisterInterfaces(registry)
 }
 
+func (nc nftClient) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	RegisterLegacyAminoCodec(cdc)
+}
+
 func (nc nftClient) IssueDenom(request IssueDenomRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return nc.IssueDenomContext(context.Background(), request, baseTx)
+}
+
+func (nc nftClient) IssueDenomContext(ctx context.Context, request IssueDenomRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	sender, err := nc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	msg := &MsgIssueClass{
+	msg, err := nc.IssueDenomMsg(sender.String(), request)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return nc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// IssueDenomMsg returns the msg of IssueDenom for the account `owner`
+func (nc nftClient) IssueDenomMsg(owner string, request IssueDenomRequest) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(owner); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	return &MsgIssueClass{
 		ID:             request.ID,
 		Name:           request.Name,
 		Schema:         request.Schema,
-		Owner:          sender.String(),
+		Owner:          owner,
 		Symbol:         request.Symbol,
 		MintRestricted: request.MintRestricted,
 		EditRestricted: request.EditRestricted,
-	}
-	return nc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 func (nc nftClient) MintNFT(request MintNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return nc.MintNFTContext(context.Background(), request, baseTx)
+}
+
+func (nc nftClient) MintNFTContext(ctx context.Context, request MintNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	sender, err := nc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	var recipient = sender.String()
+	msg, err := nc.MintNFTMsg(sender.String(), request)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return nc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// MintNFTMsg returns the msg of MintNFT for the account `owner`
+func (nc nftClient) MintNFTMsg(owner string, request MintNFTRequest) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(owner); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	var recipient = owner
 	if len(request.Recipient) > 0 {
 		if err := sdk.ValidateAccAddress(request.Recipient); err != nil {
-			return sdk.ResultTx{}, sdk.Wrap(err)
+			return nil, sdk.Wrap(err)
 		}
 		recipient = request.Recipient
 	}
 
-	msg := &MsgIssueNFT{
+	return &MsgIssueNFT{
 		ID:        request.ID,
 		ClassID:   request.ClassID,
 		Name:      request.Name,
 		URI:       request.URI,
 		Data:      request.Data,
-		Owner:     sender.String(),
+		Owner:     owner,
 		Recipient: recipient,
-	}
-	return nc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 func (nc nftClient) EditNFT(request EditNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return nc.EditNFTContext(context.Background(), request, baseTx)
+}
+
+func (nc nftClient) EditNFTContext(ctx context.Context, request EditNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	sender, err := nc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	msg := &MsgEditNFT{
+	msg, err := nc.EditNFTMsg(sender.String(), request)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return nc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// EditNFTMsg returns the msg of EditNFT for the account `owner`
+func (nc nftClient) EditNFTMsg(owner string, request EditNFTRequest) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(owner); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	return &MsgEditNFT{
 		ID:      request.ID,
 		Name:    request.Name,
 		ClassID: request.ClassID,
 		URI:     request.URI,
 		Data:    request.Data,
-		Owner:   sender.String(),
-	}
-	return nc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+		Owner:   owner,
+	}, nil
 }
 
 func (nc nftClient) TransferNFT(request TransferNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return nc.TransferNFTContext(context.Background(), request, baseTx)
+}
+
+func (nc nftClient) TransferNFTContext(ctx context.Context, request TransferNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	sender, err := nc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
+	msg, err := nc.TransferNFTMsg(sender.String(), request)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return nc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// TransferNFTMsg returns the msg of TransferNFT for the account `owner`
+func (nc nftClient) TransferNFTMsg(owner string, request TransferNFTRequest) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(owner); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
 	if err := sdk.ValidateAccAddress(request.Recipient); err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return nil, sdk.Wrap(err)
 	}
 
-	msg := &MsgTransferNFT{
+	return &MsgTransferNFT{
 		ID:        request.ID,
 		ClassID:   request.ClassID,
 		Recipient: request.Recipient,
-		Owner:     sender.String(),
-	}
-	return nc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+		Owner:     owner,
+	}, nil
 }
 
 func (nc nftClient) TransferClass(request TransferClassRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return nc.TransferClassContext(context.Background(), request, baseTx)
+}
+
+func (nc nftClient) TransferClassContext(ctx context.Context, request TransferClassRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	sender, err := nc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
+	msg, err := nc.TransferClassMsg(sender.String(), request)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return nc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// TransferClassMsg returns the msg of TransferClass for the account `owner`
+func (nc nftClient) TransferClassMsg(owner string, request TransferClassRequest) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(owner); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
 	if err := sdk.ValidateAccAddress(request.Recipient); err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return nil, sdk.Wrap(err)
 	}
 
-	msg := &MsgTransferClass{
+	return &MsgTransferClass{
 		ID:        request.ID,
 		Recipient: request.Recipient,
-		Owner:     sender.String(),
-	}
-	return nc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+		Owner:     owner,
+	}, nil
 }
 
 func (nc nftClient) BurnNFT(request BurnNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return nc.BurnNFTContext(context.Background(), request, baseTx)
+}
+
+func (nc nftClient) BurnNFTContext(ctx context.Context, request BurnNFTRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	sender, err := nc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	msg := &MsgBurnNFT{
-		Owner:   sender.String(),
+	msg, err := nc.BurnNFTMsg(sender.String(), request)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return nc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// BurnNFTMsg returns the msg of BurnNFT for the account `owner`
+func (nc nftClient) BurnNFTMsg(owner string, request BurnNFTRequest) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(owner); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	return &MsgBurnNFT{
+		Owner:   owner,
 		ID:      request.ID,
 		ClassID: request.ClassID,
-	}
-	return nc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 func (nc nftClient) QuerySupply(denom string) (uint64, sdk.Error) {
+	return nc.QuerySupplyContext(context.Background(), denom)
+}
+
+func (nc nftClient) QuerySupplyContext(ctx context.Context, denom string) (uint64, sdk.Error) {
 	if len(denom) == 0 {
 		return 0, sdk.Wrapf("denom is required")
 	}
 
 	conn, err := nc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return 0, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Supply(
-		context.Background(),
+		ctx,
 		&QuerySupplyRequest{
 			ClassId: denom,
 		},
@@ -166,6 +269,10 @@ func (nc nftClient) QuerySupply(denom string) (uint64, sdk.Error) {
 }
 
 func (nc nftClient) QueryOwner(creator, denom string, req sdk.PageRequest) (QueryOwnerResp, sdk.Error) {
+	return nc.QueryOwnerContext(context.Background(), creator, denom, req)
+}
+
+func (nc nftClient) QueryOwnerContext(ctx context.Context, creator, denom string, req sdk.PageRequest) (QueryOwnerResp, sdk.Error) {
 	if len(denom) == 0 {
 		return QueryOwnerResp{}, sdk.Wrapf("denom is required")
 	}
@@ -175,13 +282,12 @@ func (nc nftClient) QueryOwner(creator, denom string, req sdk.PageRequest) (Quer
 	}
 
 	conn, err := nc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryOwnerResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Owner(
-		context.Background(),
+		ctx,
 		&QueryOwnerRequest{
 			Address: creator,
 			ClassId: denom,
@@ -201,18 +307,21 @@ func (nc nftClient) QueryOwner(creator, denom string, req sdk.PageRequest) (Quer
 }
 
 func (nc nftClient) QueryCollection(denom string, req sdk.PageRequest) (QueryCollectionResp, sdk.Error) {
+	return nc.QueryCollectionContext(context.Background(), denom, req)
+}
+
+func (nc nftClient) QueryCollectionContext(ctx context.Context, denom string, req sdk.PageRequest) (QueryCollectionResp, sdk.Error) {
 	if len(denom) == 0 {
 		return QueryCollectionResp{}, sdk.Wrapf("denom is required")
 	}
 
 	conn, err := nc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryCollectionResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Collection(
-		context.Background(),
+		ctx,
 		&QueryCollectionRequest{
 			ClassId: denom,
 			Pagination: &query.PageRequest{
@@ -230,14 +339,17 @@ func (nc nftClient) QueryCollection(denom string, req sdk.PageRequest) (QueryCol
 }
 
 func (nc nftClient) QueryDenoms(req sdk.PageRequest) ([]QueryDenomResp, sdk.Error) {
+	return nc.QueryDenomsContext(context.Background(), req)
+}
+
+func (nc nftClient) QueryDenomsContext(ctx context.Context, req sdk.PageRequest) ([]QueryDenomResp, sdk.Error) {
 	conn, err := nc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return nil, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Classes(
-		context.Background(),
+		ctx,
 		&QueryClassesRequest{
 			Pagination: &query.PageRequest{
 				Key:        req.Key,
@@ -254,14 +366,17 @@ func (nc nftClient) QueryDenoms(req sdk.PageRequest) ([]QueryDenomResp, sdk.Erro
 }
 
 func (nc nftClient) QueryDenom(denom string) (QueryDenomResp, sdk.Error) {
+	return nc.QueryDenomContext(context.Background(), denom)
+}
+
+func (nc nftClient) QueryDenomContext(ctx context.Context, denom string) (QueryDenomResp, sdk.Error) {
 	conn, err := nc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryDenomResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Class(
-		context.Background(),
+		ctx,
 		&QueryClassRequest{ClassId: denom},
 	)
 	if err != nil {
@@ -272,6 +387,10 @@ func (nc nftClient) QueryDenom(denom string) (QueryDenomResp, sdk.Error) {
 }
 
 func (nc nftClient) QueryNFT(denom, ID string) (QueryNFTResp, sdk.Error) {
+	return nc.QueryNFTContext(context.Background(), denom, ID)
+}
+
+func (nc nftClient) QueryNFTContext(ctx context.Context, denom, ID string) (QueryNFTResp, sdk.Error) {
 	if len(denom) == 0 {
 		return QueryNFTResp{}, sdk.Wrapf("denom is required")
 	}
@@ -281,13 +400,12 @@ func (nc nftClient) QueryNFT(denom, ID string) (QueryNFTResp, sdk.Error) {
 	}
 
 	conn, err := nc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryNFTResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).NFT(
-		context.Background(),
+		ctx,
 		&QueryNFTRequest{
 			ClassId: denom,
 			NftId:   ID,
@@ -0,0 +1,140 @@
+package modules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	clienttx "plugchain-sdk-go/client/tx"
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/tx"
+)
+
+func (base *baseClient) BuildUnsignedTx(addr string, msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte, sdk.Error) {
+	return base.BuildUnsignedTxContext(context.Background(), addr, msg, baseTx)
+}
+
+// BuildUnsignedTxContext builds a tx of the account at addr without signing it, no key is needed.
+// The account number and the sequence are queried unless they are set on baseTx,
+// and the gas is not simulated, so it should be set on baseTx or on ClientConfig.
+func (base *baseClient) BuildUnsignedTxContext(ctx context.Context, addr string, msg []sdk.Msg, baseTx sdk.BaseTx) ([]byte, sdk.Error) {
+	if _, err := sdk.AccAddressFromBech32(addr); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	if len(baseTx.FeePayer) > 0 {
+		return nil, sdk.Wrapf("a fee payer is not supported by offline signing")
+	}
+
+	accountNumber, sequence := baseTx.AccountNumber, baseTx.Sequence
+	if accountNumber == 0 && sequence == 0 {
+		seq, err := base.sequences.acquire(ctx, addr, base.queryLatestAccount)
+		if err != nil {
+			return nil, err
+		}
+		accountNumber, sequence = seq.accountNumber, seq.sequence
+		seq.Unlock()
+	}
+
+	builder, err := base.prepareTemp(ctx, addr, accountNumber, sequence, baseTx, nil)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	txBuilder, err := builder.BuildUnsignedTx(msg)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	txJSON, err := base.encodingConfig.TxConfig.TxJSONEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	bz, err := json.Marshal(sdk.UnsignedTx{
+		ChainID:       builder.ChainID(),
+		AccountNumber: accountNumber,
+		Sequence:      sequence,
+		SignMode:      baseTx.SignMode,
+		Tx:            txJSON,
+	})
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return bz, nil
+}
+
+// SignTx signs a tx built by BuildUnsignedTx with the key name of the KeyDAO and returns
+// the signed tx in JSON, no request is sent to the node
+func (base *baseClient) SignTx(unsignedTx []byte, name, password string) ([]byte, sdk.Error) {
+	unsigned, txBuilder, e := base.decodeUnsignedTx(unsignedTx)
+	if e != nil {
+		return nil, e
+	}
+
+	txConfig := base.encodingConfig.TxConfig
+	builder := clienttx.NewFactory().
+		WithChainID(unsigned.ChainID).
+		WithAccountNumber(unsigned.AccountNumber).
+		WithSequence(unsigned.Sequence).
+		WithPassword(password).
+		WithSignMode(unsigned.SignMode).
+		WithKeyManager(base.KeyManager).
+		WithSignModeHandler(tx.MakeSignModeHandler(tx.DefaultSignModes)).
+		WithTxConfig(txConfig)
+	if err := builder.Sign(name, txBuilder); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	bz, err := txConfig.TxJSONEncoder()(txBuilder.GetTx())
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return bz, nil
+}
+
+// decodeUnsignedTx decodes a tx built by BuildUnsignedTx
+func (base *baseClient) decodeUnsignedTx(unsignedTx []byte) (sdk.UnsignedTx, sdk.TxBuilder, sdk.Error) {
+	var unsigned sdk.UnsignedTx
+	if err := json.Unmarshal(unsignedTx, &unsigned); err != nil {
+		return unsigned, nil, sdk.Wrap(err)
+	}
+
+	txConfig := base.encodingConfig.TxConfig
+	decoded, err := txConfig.TxJSONDecoder()(unsigned.Tx)
+	if err != nil {
+		return unsigned, nil, sdk.Wrap(err)
+	}
+
+	txBuilder, err := txConfig.WrapTxBuilder(decoded)
+	if err != nil {
+		return unsigned, nil, sdk.Wrap(err)
+	}
+	return unsigned, txBuilder, nil
+}
+
+func (base *baseClient) BroadcastSignedTx(signedTx []byte, mode sdk.BroadcastMode) (sdk.ResultTx, sdk.Error) {
+	return base.BroadcastSignedTxContext(context.Background(), signedTx, mode)
+}
+
+// BroadcastSignedTxContext broadcasts a signed tx encoded in JSON, as returned by SignTx, or in bytes.
+// ClientConfig.Mode is used if mode is empty.
+func (base *baseClient) BroadcastSignedTxContext(ctx context.Context, signedTx []byte, mode sdk.BroadcastMode) (sdk.ResultTx, sdk.Error) {
+	txBytes := signedTx
+	// the protobuf encoding of a tx never starts with '{'
+	if trimmed := bytes.TrimSpace(signedTx); len(trimmed) > 0 && trimmed[0] == '{' {
+		txConfig := base.encodingConfig.TxConfig
+		decoded, err := txConfig.TxJSONDecoder()(trimmed)
+		if err != nil {
+			return sdk.ResultTx{}, sdk.Wrap(err)
+		}
+
+		if txBytes, err = txConfig.TxEncoder()(decoded); err != nil {
+			return sdk.ResultTx{}, sdk.Wrap(err)
+		}
+	}
+
+	if len(mode) == 0 {
+		mode = base.cfg.Mode
+	}
+	return base.broadcastTx(ctx, txBytes, mode, false)
+}
@@ -0,0 +1,206 @@
+package modules
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/store"
+	"plugchain-sdk-go/types/tx/signing"
+)
+
+// OutboxTxs returns the txs of the outbox with the status, all the txs if status is empty
+func (base *baseClient) OutboxTxs(status store.OutboxStatus) ([]store.OutboxEntry, sdk.Error) {
+	outbox := base.cfg.Outbox
+	if outbox == nil {
+		return nil, sdk.Wrapf("outbox is not configured")
+	}
+
+	entries, err := outbox.List(status)
+	if err != nil {
+		return nil, sdk.Wrap(err)
+	}
+	return entries, nil
+}
+
+// RemoveOutboxTx removes the tx from the outbox, e.g. a failed tx handled by the app
+func (base *baseClient) RemoveOutboxTx(hash string) sdk.Error {
+	outbox := base.cfg.Outbox
+	if outbox == nil {
+		return sdk.Wrapf("outbox is not configured")
+	}
+	return sdk.Wrap(outbox.Delete(hash))
+}
+
+func (base *baseClient) ResubmitOutbox() ([]store.OutboxEntry, sdk.Error) {
+	return base.ResubmitOutboxContext(context.Background())
+}
+
+// ResubmitOutboxContext confirms the pending txs of the outbox, e.g. after a restart: the txs included in a
+// block are removed, or marked as failed if they failed, and the others are broadcast again in the order of
+// their sequences. A tx that can no longer be included, because its timeout height is reached or the node
+// rejects it, is marked as failed. The txs still pending are returned.
+func (base *baseClient) ResubmitOutboxContext(ctx context.Context) ([]store.OutboxEntry, sdk.Error) {
+	entries, err := base.OutboxTxs(store.OutboxPending)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	status, e := base.Status(ctx)
+	if e != nil {
+		return nil, sdk.Wrap(e)
+	}
+	height := uint64(status.SyncInfo.LatestBlockHeight)
+
+	var pending []store.OutboxEntry
+	for _, entry := range entries {
+		res, e := base.QueryTxContext(ctx, entry.Hash)
+		if e == nil {
+			if res.Result.Code == 0 {
+				err = base.RemoveOutboxTx(entry.Hash)
+			} else {
+				err = base.failOutboxTx(entry, res.Result.Log)
+			}
+			if err != nil {
+				return pending, err
+			}
+			continue
+		}
+		if !errors.Is(e, sdk.ErrNotFound) {
+			return pending, sdk.Wrap(e)
+		}
+
+		if entry.TimeoutHeight > 0 && height >= entry.TimeoutHeight {
+			if err := base.failOutboxTx(entry, "timeout height reached"); err != nil {
+				return pending, err
+			}
+			continue
+		}
+
+		_, err := base.broadcastTxSync(ctx, entry.TxBytes)
+		var abciErr sdk.ABCIError
+		switch {
+		case err == nil, errors.Is(err, sdk.ErrTxInMempoolCache):
+			base.Logger().Info("tx of the outbox broadcast again", "hash", entry.Hash, "sequence", entry.Sequence)
+			pending = append(pending, entry)
+		case errors.As(err, &abciErr):
+			if err := base.failOutboxTx(entry, err.Error()); err != nil {
+				return pending, err
+			}
+		default:
+			return pending, err
+		}
+	}
+	return pending, nil
+}
+
+// recordOutbox saves the tx as pending before it is broadcast
+func (base baseClient) recordOutbox(txBytes []byte) sdk.Error {
+	outbox := base.cfg.Outbox
+	if outbox == nil {
+		return nil
+	}
+
+	tx, err := base.encodingConfig.TxConfig.TxDecoder()(txBytes)
+	if err != nil {
+		return sdk.Wrap(err)
+	}
+
+	entry := store.OutboxEntry{
+		Hash:      txHash(txBytes),
+		TxBytes:   txBytes,
+		Status:    store.OutboxPending,
+		CreatedAt: time.Now(),
+	}
+	if tx, ok := tx.(sdk.TxWithTimeoutHeight); ok {
+		entry.TimeoutHeight = tx.GetTimeoutHeight()
+	}
+	// the signer and the sequence are those of the first signature, the signer of the msgs
+	if tx, ok := tx.(interface {
+		GetSigners() []sdk.AccAddress
+		GetSignaturesV2() ([]signing.SignatureV2, error)
+	}); ok {
+		if signers := tx.GetSigners(); len(signers) > 0 {
+			entry.Address = signers[0].String()
+		}
+		if sigs, err := tx.GetSignaturesV2(); err == nil && len(sigs) > 0 {
+			entry.Sequence = sigs[0].Sequence
+		}
+	}
+	return sdk.Wrap(outbox.Put(entry))
+}
+
+// updateOutbox removes the tx from the outbox once the result of the broadcast is known to the caller,
+// the tx stays pending if it is not committed yet or if it is unknown whether the node received it, and
+// is marked as failed if it is committed but failed in DeliverTx
+func (base baseClient) updateOutbox(txBytes []byte, mode sdk.BroadcastMode, res sdk.ResultTx, err sdk.Error) {
+	outbox := base.cfg.Outbox
+	if outbox == nil {
+		return
+	}
+
+	var abciErr sdk.ABCIError
+	switch {
+	case err == nil && mode != sdk.Commit:
+		// confirmed by WaitForTx or ResubmitOutbox
+		return
+	case mode == sdk.Commit && res.Code != 0:
+		base.confirmOutbox(res)
+		return
+	case err != nil && (!errors.As(err, &abciErr) || errors.Is(err, sdk.ErrTxInMempoolCache)):
+		// the node may have received the tx
+		return
+	}
+
+	hash := txHash(txBytes)
+	if err := outbox.Delete(hash); err != nil {
+		base.Logger().Error("remove tx from the outbox failed", "hash", hash, "errMsg", err.Error())
+	}
+}
+
+// confirmOutbox removes a tx included in a block from the outbox, or marks it as failed if it failed
+// in DeliverTx, so that the app can handle it
+func (base baseClient) confirmOutbox(res sdk.ResultTx) {
+	outbox := base.cfg.Outbox
+	if outbox == nil {
+		return
+	}
+
+	if res.Code == 0 {
+		if err := outbox.Delete(res.Hash); err != nil {
+			base.Logger().Error("remove tx from the outbox failed", "hash", res.Hash, "errMsg", err.Error())
+		}
+		return
+	}
+
+	entry, ok, err := outbox.Get(res.Hash)
+	if err != nil {
+		base.Logger().Error("get tx of the outbox failed", "hash", res.Hash, "errMsg", err.Error())
+		return
+	}
+	if !ok {
+		return
+	}
+	if err := base.failOutboxTx(entry, res.Log); err != nil {
+		base.Logger().Error("mark tx of the outbox as failed failed", "hash", res.Hash, "errMsg", err.Error())
+	}
+}
+
+func (base *baseClient) failOutboxTx(entry store.OutboxEntry, log string) sdk.Error {
+	base.Logger().Info("tx of the outbox failed", "hash", entry.Hash, "log", log)
+	entry.Status = store.OutboxFailed
+	entry.Log = log
+	return sdk.Wrap(base.cfg.Outbox.Put(entry))
+}
+
+func txHash(txBytes []byte) string {
+	return strings.ToUpper(hex.EncodeToString(tmhash.Sum(txBytes)))
+}
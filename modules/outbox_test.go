@@ -0,0 +1,139 @@
+package modules
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"plugchain-sdk-go/codec"
+	cdctypes "plugchain-sdk-go/codec/types"
+	cryptocodec "plugchain-sdk-go/crypto/codec"
+	"plugchain-sdk-go/modules/bank"
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/store"
+	txtypes "plugchain-sdk-go/types/tx"
+	"plugchain-sdk-go/utils/rpctest"
+)
+
+func TestOutbox(t *testing.T) {
+	// the node knows no tx until delivered is set, which then failed in DeliverTx
+	var broadcasts int32
+	var delivered atomic.Value
+	node := rpctest.NewNode(t, map[string]func(rpctypes.RPCRequest) rpctypes.RPCResponse{
+		"status": rpctest.Result(&ctypes.ResultStatus{
+			NodeInfo: p2p.DefaultNodeInfo{Network: "chaintest-1"},
+			SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 10},
+		}),
+		"broadcast_tx_sync": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			atomic.AddInt32(&broadcasts, 1)
+			return rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultBroadcastTx{})
+		},
+		"tx": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			txBytes, ok := delivered.Load().([]byte)
+			if !ok {
+				return rpctypes.NewRPCErrorResponse(req.ID, -32603, "Internal error", "tx not found")
+			}
+			return rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultTx{
+				Hash:     tmtypes.Tx(txBytes).Hash(),
+				Height:   10,
+				Tx:       txBytes,
+				TxResult: abci.ResponseDeliverTx{Code: 5, Codespace: sdk.RootCodespace, Log: "insufficient funds"},
+			})
+		},
+		"blockchain": rpctest.Result(&ctypes.ResultBlockchainInfo{
+			LastHeight: 10,
+			BlockMetas: []*tmtypes.BlockMeta{{Header: tmtypes.Header{Height: 10, Time: time.Now()}}},
+		}),
+	})
+
+	outbox, err := store.NewLevelDBOutbox(t.TempDir())
+	require.NoError(t, err)
+	defer outbox.Close()
+
+	cfg, err := sdk.NewClientConfig(node.URL, "127.0.0.1:1", "chaintest-1",
+		sdk.KeyDAOOption(store.NewMemory(nil)),
+		sdk.OutboxOption(outbox),
+	)
+	require.NoError(t, err)
+
+	registry := cdctypes.NewInterfaceRegistry()
+	registry.RegisterInterface("cosmos.v1beta1.Msg", (*sdk.Msg)(nil))
+	txtypes.RegisterInterfaces(registry)
+	cryptocodec.RegisterInterfaces(registry)
+	bank.RegisterInterfaces(registry)
+	marshaler := codec.NewProtoCodec(registry)
+	client := NewBaseClient(cfg, sdk.EncodingConfig{
+		InterfaceRegistry: registry,
+		Marshaler:         marshaler,
+		TxConfig:          txtypes.NewTxConfig(marshaler, txtypes.DefaultSignModes),
+		Amino:             codec.NewLegacyAmino(),
+	}, log.NewNopLogger()).(*baseClient)
+	defer client.Close()
+
+	from, _, err := client.Insert("outbox", "password")
+	require.NoError(t, err)
+
+	// the second tx expires at the height 5, before the latest block
+	for i, timeoutHeight := range []uint64{0, 5} {
+		msg := &bank.MsgSend{
+			FromAddress: from,
+			ToAddress:   from,
+			Amount:      sdk.NewCoins(sdk.NewCoin("plug", sdk.NewInt(1))),
+		}
+		unsignedTx, e := client.BuildUnsignedTx(from, []sdk.Msg{msg}, sdk.BaseTx{
+			AccountNumber: 3,
+			Sequence:      5 + uint64(i),
+			TimeoutHeight: timeoutHeight,
+		})
+		require.Nil(t, e)
+		signedTx, e := client.SignTx(unsignedTx, "outbox", "password")
+		require.Nil(t, e)
+		_, e = client.BroadcastSignedTx(signedTx, sdk.Sync)
+		require.Nil(t, e)
+	}
+
+	pending, e := client.OutboxTxs(store.OutboxPending)
+	require.Nil(t, e)
+	require.Len(t, pending, 2)
+	require.Equal(t, from, pending[0].Address)
+	require.Equal(t, uint64(5), pending[0].Sequence)
+	require.Equal(t, uint64(6), pending[1].Sequence)
+	require.Equal(t, uint64(5), pending[1].TimeoutHeight)
+	hashes := []string{pending[0].Hash, pending[1].Hash}
+
+	// after a restart, the txs not included are broadcast again unless they expired
+	pending, e = client.ResubmitOutbox()
+	require.Nil(t, e)
+	require.Len(t, pending, 1)
+	require.Equal(t, hashes[0], pending[0].Hash)
+	require.Equal(t, int32(3), atomic.LoadInt32(&broadcasts))
+
+	failed, e := client.OutboxTxs(store.OutboxFailed)
+	require.Nil(t, e)
+	require.Len(t, failed, 1)
+	require.Equal(t, hashes[1], failed[0].Hash)
+
+	require.Nil(t, client.RemoveOutboxTx(hashes[1]))
+	all, e := client.OutboxTxs("")
+	require.Nil(t, e)
+	require.Len(t, all, 1)
+
+	// a tx failed in DeliverTx stays in the outbox as failed
+	delivered.Store(pending[0].TxBytes)
+	_, e = client.WaitForTx(hashes[0], 5*time.Second)
+	require.True(t, errors.Is(e, sdk.ErrInsufficientFunds))
+	failed, e = client.OutboxTxs(store.OutboxFailed)
+	require.Nil(t, e)
+	require.Len(t, failed, 1)
+	require.Equal(t, hashes[0], failed[0].Hash)
+	require.Equal(t, "insufficient funds", failed[0].Log)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	rpc "github.com/tendermint/tendermint/rpc/client"
 	rpchttp "github.com/tendermint/tendermint/rpc/client/http"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 	tmtypes "github.com/tendermint/tendermint/types"
 	"plugchain-sdk-go/codec"
 	sdk "plugchain-sdk-go/types"
@@ -19,38 +20,63 @@ type rpcClient struct {
 	log.Logger
 	cdc       *codec.LegacyAmino
 	txDecoder sdk.TxDecoder
+	ws        *wsClient
+	// subscribeOpts applies to the subscriptions with a handler
+	subscribeOpts sdk.SubscribeOptions
 }
 
 func NewRPCClient(remote string, cdc *codec.LegacyAmino, txDecoder sdk.TxDecoder, logger log.Logger, timeout uint) sdk.TmClient {
-	client, err := rpchttp.NewWithTimeout(remote, "/websocket", timeout)
+	client, err := newRPCClient(remote, cdc, txDecoder, logger, timeout)
 	if err != nil {
 		panic(err)
 	}
-	_ = client.Start()
+	return client
+}
+
+func newRPCClient(remote string, cdc *codec.LegacyAmino, txDecoder sdk.TxDecoder, logger log.Logger, timeout uint) (rpcClient, error) {
+	client, err := rpchttp.NewWithTimeout(remote, wsEndpoint, timeout)
+	if err != nil {
+		return rpcClient{}, err
+	}
+	// the subscriptions use their own websocket, reconnected when it is lost
+	ws, err := newWSClient(remote, logger)
+	if err != nil {
+		return rpcClient{}, err
+	}
 	return rpcClient{
 		Client:    client,
 		Logger:    logger,
 		cdc:       cdc,
 		txDecoder: txDecoder,
-	}
+		ws:        ws,
+	}, nil
+}
+
+// Stop closes the websocket of the subscriptions
+func (r rpcClient) Stop() error {
+	r.ws.stop()
+	return nil
+}
+
+func (r rpcClient) OnWSStatusChange(handler sdk.WSStatusHandler) {
+	r.ws.setStatusHandler(handler)
 }
 
 func (r rpcClient) SubscribeNewBlock(builder *sdk.EventQueryBuilder, handler sdk.EventNewBlockHandler) (sdk.Subscription, sdk.Error) {
-	if builder == nil {
-		builder = sdk.NewEventQueryBuilder()
+	query, err := newBlockQuery(builder)
+	if err != nil {
+		return sdk.Subscription{}, err
 	}
-	builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(tmtypes.EventNewBlock))
-	query := builder.Build()
 	return r.SubscribeAny(query, func(data sdk.EventData) {
 		handler(data.(sdk.EventDataNewBlock))
 	})
 }
 
 func (r rpcClient) SubscribeTx(builder *sdk.EventQueryBuilder, handler sdk.EventTxHandler) (sdk.Subscription, sdk.Error) {
-	if builder == nil {
-		builder = sdk.NewEventQueryBuilder()
+	query, err := txQuery(builder)
+	if err != nil {
+		return sdk.Subscription{}, err
 	}
-	query := builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(sdk.TxValue)).Build()
 	return r.SubscribeAny(query, func(data sdk.EventData) {
 		handler(data.(sdk.EventDataTx))
 	})
@@ -76,8 +102,12 @@ func (r rpcClient) Resubscribe(subscription sdk.Subscription, handler sdk.EventH
 }
 
 func (r rpcClient) Unsubscribe(subscription sdk.Subscription) sdk.Error {
+	return r.UnsubscribeContext(context.Background(), subscription)
+}
+
+func (r rpcClient) UnsubscribeContext(ctx context.Context, subscription sdk.Subscription) sdk.Error {
 	r.Info("end to subscribe event", "query", subscription.Query, "subscriber", subscription.ID)
-	err := r.Client.Unsubscribe(subscription.Ctx, subscription.ID, subscription.Query)
+	err := r.ws.unsubscribe(ctx, subscription.ID)
 	if err != nil {
 		r.Error("unsubscribe failed", "query", subscription.Query, "subscriber", subscription.ID, "errMsg", err.Error())
 		return sdk.Wrap(err)
@@ -85,50 +115,161 @@ func (r rpcClient) Unsubscribe(subscription sdk.Subscription) sdk.Error {
 	return nil
 }
 
+// SubscribeAny calls the handler with the events of the query one at a time, in order
 func (r rpcClient) SubscribeAny(query string, handler sdk.EventHandler) (subscription sdk.Subscription, err sdk.Error) {
-	ctx := context.Background()
-	subscriber := getSubscriber()
-	ch, e := r.Subscribe(ctx, subscriber, query, 0)
-	if e != nil {
-		return subscription, sdk.Wrap(e)
+	subscription, q, err := r.subscribe(context.Background(), query, r.subscribeOpts)
+	if err != nil {
+		return subscription, err
 	}
+	go q.handle(handler)
+	return subscription, nil
+}
 
-	r.Info("subscribe event", "query", query, "subscriber", subscription.ID)
+func (r rpcClient) SubscribeChan(ctx context.Context, query string, opts sdk.SubscribeOptions) (sdk.EventSubscription, sdk.Error) {
+	subscription, q, err := r.subscribe(ctx, query, opts)
+	if err != nil {
+		return sdk.EventSubscription{}, err
+	}
+	return sdk.EventSubscription{
+		Subscription: subscription,
+		Events:       q.events,
+	}, nil
+}
 
-	subscription = sdk.Subscription{
-		Ctx:   ctx,
-		Query: query,
-		ID:    subscriber,
+func (r rpcClient) SubscribeTxChan(ctx context.Context, builder *sdk.EventQueryBuilder, opts sdk.SubscribeOptions) (sdk.TxSubscription, sdk.Error) {
+	query, err := txQuery(builder)
+	if err != nil {
+		return sdk.TxSubscription{}, err
+	}
+	subscription, q, err := r.subscribe(ctx, query, opts)
+	if err != nil {
+		return sdk.TxSubscription{}, err
 	}
 
+	txs := make(chan sdk.EventDataTx)
 	go func() {
-		for {
-			data := <-ch
-			go func() {
-				defer sdk.CatchPanic(func(errMsg string) {
-					r.Error("unsubscribe failed", "query", subscription.Query, "subscriber", subscription.ID, "errMsg", err.Error())
-				})
-
-				switch data := data.Data.(type) {
-				case tmtypes.EventDataTx:
-					handler(r.parseTx(data))
-					return
-				case tmtypes.EventDataNewBlock:
-					handler(r.parseNewBlock(data))
-					return
-				case tmtypes.EventDataNewBlockHeader:
-					handler(r.parseNewBlockHeader(data))
+		defer close(txs)
+		for data := range q.events {
+			if tx, ok := data.(sdk.EventDataTx); ok {
+				select {
+				case txs <- tx:
+				case <-q.done:
 					return
-				case tmtypes.EventDataValidatorSetUpdates:
-					handler(r.parseValidatorSetUpdates(data))
+				}
+			}
+		}
+	}()
+	return sdk.TxSubscription{
+		Subscription: subscription,
+		Txs:          txs,
+	}, nil
+}
+
+func (r rpcClient) SubscribeNewBlockChan(ctx context.Context, builder *sdk.EventQueryBuilder, opts sdk.SubscribeOptions) (sdk.BlockSubscription, sdk.Error) {
+	query, err := newBlockQuery(builder)
+	if err != nil {
+		return sdk.BlockSubscription{}, err
+	}
+	subscription, q, err := r.subscribe(ctx, query, opts)
+	if err != nil {
+		return sdk.BlockSubscription{}, err
+	}
+
+	blocks := make(chan sdk.EventDataNewBlock)
+	go func() {
+		defer close(blocks)
+		for data := range q.events {
+			if block, ok := data.(sdk.EventDataNewBlock); ok {
+				select {
+				case blocks <- block:
+				case <-q.done:
 					return
-				default:
-					handler(data)
 				}
-			}()
+			}
 		}
 	}()
-	return
+	return sdk.BlockSubscription{
+		Subscription: subscription,
+		Blocks:       blocks,
+	}, nil
+}
+
+// subscribe queues the events of the query, the subscription ends when ctx is done
+func (r rpcClient) subscribe(ctx context.Context, query string, opts sdk.SubscribeOptions) (sdk.Subscription, *eventQueue, sdk.Error) {
+	subscriber := getSubscriber()
+	q := newEventQueue(opts)
+	e := r.ws.subscribe(ctx, subscriber, query, func(event ctypes.ResultEvent) {
+		data, err := r.parseEvent(event)
+		if err != nil {
+			q.report(err)
+			return
+		}
+		if !q.push(data) {
+			r.Error("subscription buffer is full", "query", query, "subscriber", subscriber)
+			q.close(sdk.ErrSubscriptionOverflow)
+			go func() {
+				_ = r.ws.unsubscribe(context.Background(), subscriber)
+			}()
+		}
+	}, q.close)
+	if e != nil {
+		return sdk.Subscription{}, nil, sdk.Wrap(e)
+	}
+
+	r.Info("subscribe event", "query", query, "subscriber", subscriber)
+
+	if ctx.Done() != nil {
+		go func() {
+			select {
+			case <-ctx.Done():
+				_ = r.UnsubscribeContext(context.Background(), sdk.Subscription{Query: query, ID: subscriber})
+			case <-q.done:
+			}
+		}()
+	}
+
+	return sdk.Subscription{
+		Ctx:    ctx,
+		Query:  query,
+		ID:     subscriber,
+		Errors: q.errs,
+	}, q, nil
+}
+
+// parseEvent converts the data of an event to the types of the sdk
+func (r rpcClient) parseEvent(event ctypes.ResultEvent) (data sdk.EventData, err error) {
+	defer sdk.CatchPanic(func(errMsg string) {
+		err = fmt.Errorf("parse event failed: %s", errMsg)
+	})
+
+	switch data := event.Data.(type) {
+	case tmtypes.EventDataTx:
+		return r.parseTx(data), nil
+	case tmtypes.EventDataNewBlock:
+		return r.parseNewBlock(data), nil
+	case tmtypes.EventDataNewBlockHeader:
+		return r.parseNewBlockHeader(data), nil
+	case tmtypes.EventDataValidatorSetUpdates:
+		return r.parseValidatorSetUpdates(data), nil
+	default:
+		return data, nil
+	}
+}
+
+func txQuery(builder *sdk.EventQueryBuilder) (string, sdk.Error) {
+	if builder == nil {
+		builder = sdk.NewEventQueryBuilder()
+	}
+	builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(sdk.TxValue))
+	return builder.Build(), builder.Err()
+}
+
+func newBlockQuery(builder *sdk.EventQueryBuilder) (string, sdk.Error) {
+	if builder == nil {
+		builder = sdk.NewEventQueryBuilder()
+	}
+	builder.AddCondition(sdk.Cond(sdk.TypeKey).EQ(tmtypes.EventNewBlock))
+	return builder.Build(), builder.Err()
 }
 
 func (r rpcClient) parseTx(data sdk.EventData) sdk.EventDataTx {
@@ -140,11 +281,13 @@ func (r rpcClient) parseTx(data sdk.EventData) sdk.EventDataTx {
 
 	hash := sdk.HexBytes(tmhash.Sum(dataTx.Tx)).String()
 	result := sdk.TxResult{
-		Code:      dataTx.Result.Code,
-		Log:       dataTx.Result.Log,
-		GasWanted: dataTx.Result.GasWanted,
-		GasUsed:   dataTx.Result.GasUsed,
-		Events:    sdk.StringifyEvents(dataTx.Result.Events),
+		Code:       dataTx.Result.Code,
+		Codespace:  dataTx.Result.Codespace,
+		Log:        dataTx.Result.Log,
+		GasWanted:  dataTx.Result.GasWanted,
+		GasUsed:    dataTx.Result.GasUsed,
+		Events:     sdk.StringifyEvents(dataTx.Result.Events),
+		ABCIEvents: dataTx.Result.Events,
 	}
 	return sdk.EventDataTx{
 		Hash:   hash,
@@ -0,0 +1,544 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tmbytes "github.com/tendermint/tendermint/libs/bytes"
+	"github.com/tendermint/tendermint/libs/log"
+	rpc "github.com/tendermint/tendermint/rpc/client"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"plugchain-sdk-go/codec"
+	sdk "plugchain-sdk-go/types"
+)
+
+// rpcPool spreads the tendermint rpc requests over several nodes of the same chain.
+// The nodes are checked periodically via Status, a node is skipped while it is
+// unreachable, catching up or serving another chain, and a request that fails at
+// the transport level is retried on the next node.
+type rpcPool struct {
+	log.Logger
+	chainID  string
+	timeout  time.Duration
+	remotes  int
+	nodes    []*rpcNode
+	next     uint32
+	mtx      sync.Mutex
+	onHealth func(healthy []bool)
+	quit     chan struct{}
+	stopOnce sync.Once
+}
+
+type rpcNode struct {
+	rpcClient
+	index   int
+	url     string
+	healthy int32
+}
+
+func (n *rpcNode) isHealthy() bool {
+	return atomic.LoadInt32(&n.healthy) == 1
+}
+
+// setHealthy updates the health of the node and returns whether it changed
+func (n *rpcNode) setHealthy(healthy bool) bool {
+	var v int32
+	if healthy {
+		v = 1
+	}
+	return atomic.SwapInt32(&n.healthy, v) != v
+}
+
+// NewRPCPool creates a client balancing over the given remotes. Remotes that can not be
+// parsed are skipped, an error is returned only if none of them is usable.
+// The periodic health check is disabled if interval is not positive.
+func NewRPCPool(remotes []string, chainID string, cdc *codec.LegacyAmino, txDecoder sdk.TxDecoder,
+	logger log.Logger, timeout uint, interval time.Duration) (*rpcPool, error) {
+	pool := &rpcPool{
+		Logger:  logger,
+		chainID: chainID,
+		timeout: time.Duration(timeout) * time.Second,
+		remotes: len(remotes),
+		quit:    make(chan struct{}),
+	}
+
+	for i, remote := range remotes {
+		client, err := newRPCClient(remote, cdc, txDecoder, logger, timeout)
+		if err != nil {
+			logger.Error("invalid rpc node", "node", remote, "errMsg", err.Error())
+			continue
+		}
+		pool.nodes = append(pool.nodes, &rpcNode{
+			rpcClient: client,
+			index:     i,
+			url:       remote,
+			healthy:   1,
+		})
+	}
+
+	if len(pool.nodes) == 0 {
+		return nil, errors.New("no available rpc node")
+	}
+
+	pool.checkHealth()
+	if interval > 0 {
+		go pool.healthLoop(interval)
+	}
+	return pool, nil
+}
+
+// OnHealthChange registers a callback invoked with the health of every node,
+// in the order of the remotes, whenever it changes
+func (p *rpcPool) OnHealthChange(fn func(healthy []bool)) {
+	p.mtx.Lock()
+	p.onHealth = fn
+	p.mtx.Unlock()
+
+	fn(p.health())
+}
+
+// Stop stops the health check and all the rpc clients
+func (p *rpcPool) Stop() (err error) {
+	p.stopOnce.Do(func() {
+		close(p.quit)
+		for _, n := range p.nodes {
+			if e := n.Stop(); e != nil {
+				err = e
+			}
+		}
+	})
+	return err
+}
+
+func (p *rpcPool) healthLoop(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-p.quit:
+			return
+		case <-ticker.C:
+			p.checkHealth()
+		}
+	}
+}
+
+func (p *rpcPool) checkHealth() {
+	var changed int32
+	var wg sync.WaitGroup
+	for _, n := range p.nodes {
+		wg.Add(1)
+		go func(n *rpcNode) {
+			defer wg.Done()
+			err := p.checkNode(n)
+			if n.setHealthy(err == nil) {
+				atomic.StoreInt32(&changed, 1)
+				if err != nil {
+					p.Error("rpc node is unhealthy", "node", n.url, "errMsg", err.Error())
+				} else {
+					p.Info("rpc node is healthy", "node", n.url)
+				}
+			}
+		}(n)
+	}
+	wg.Wait()
+
+	if atomic.LoadInt32(&changed) == 1 {
+		p.notifyHealth()
+	}
+}
+
+func (p *rpcPool) checkNode(n *rpcNode) error {
+	ctx := context.Background()
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+
+	status, err := n.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.SyncInfo.CatchingUp {
+		return errors.New("node is catching up")
+	}
+	if len(p.chainID) > 0 && status.NodeInfo.Network != p.chainID {
+		return fmt.Errorf("node is on chain %s, expected %s", status.NodeInfo.Network, p.chainID)
+	}
+	return nil
+}
+
+// health returns the health of every remote passed to NewRPCPool, skipped remotes are unhealthy
+func (p *rpcPool) health() []bool {
+	healthy := make([]bool, p.remotes)
+	for _, n := range p.nodes {
+		healthy[n.index] = n.isHealthy()
+	}
+	return healthy
+}
+
+func (p *rpcPool) notifyHealth() {
+	p.mtx.Lock()
+	fn := p.onHealth
+	p.mtx.Unlock()
+
+	if fn != nil {
+		fn(p.health())
+	}
+}
+
+func (p *rpcPool) markUnhealthy(n *rpcNode, err error) {
+	if n.setHealthy(false) {
+		p.Error("rpc node is unavailable, failover to the next node", "node", n.url, "errMsg", err.Error())
+		p.notifyHealth()
+	}
+}
+
+// candidates returns the nodes in the order they should be tried: the healthy
+// nodes starting from the round-robin position, followed by the unhealthy ones
+// as a last resort
+func (p *rpcPool) candidates() []*rpcNode {
+	size := len(p.nodes)
+	start := int(atomic.AddUint32(&p.next, 1))
+
+	healthy := make([]*rpcNode, 0, size)
+	var unhealthy []*rpcNode
+	for i := 0; i < size; i++ {
+		n := p.nodes[(start+i)%size]
+		if n.isHealthy() {
+			healthy = append(healthy, n)
+		} else {
+			unhealthy = append(unhealthy, n)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+func (p *rpcPool) do(ctx context.Context, fn func(n *rpcNode) error) error {
+	return p.try(ctx, isTransportError, fn)
+}
+
+// doBroadcast only retries a tx on the next node if it could not be sent: a tx which may
+// have been received would be resubmitted and rejected as already in the cache of the node
+func (p *rpcPool) doBroadcast(ctx context.Context, fn func(n *rpcNode) error) error {
+	return p.try(ctx, isDialError, fn)
+}
+
+func (p *rpcPool) try(ctx context.Context, retry func(ctx context.Context, err error) bool,
+	fn func(n *rpcNode) error) (err error) {
+	for _, n := range p.candidates() {
+		if err = fn(n); err == nil || !retry(ctx, err) {
+			return err
+		}
+		p.markUnhealthy(n, err)
+	}
+	return err
+}
+
+// isTransportError returns whether err is caused by the node being unreachable,
+// errors returned by the node itself and cancellation by the caller are not retried
+func isTransportError(ctx context.Context, err error) bool {
+	if ctx != nil && ctx.Err() != nil {
+		return false
+	}
+	var rpcErr *rpctypes.RPCError
+	return !errors.As(err, &rpcErr)
+}
+
+// isDialError returns whether err is caused by a failed connection to the node, the request
+// was then never received. Timeouts and broken responses are ambiguous and not retried.
+func isDialError(ctx context.Context, err error) bool {
+	if ctx != nil && ctx.Err() != nil {
+		return false
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+func (p *rpcPool) ABCIInfo(ctx context.Context) (res *ctypes.ResultABCIInfo, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.ABCIInfo(ctx)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) ABCIQuery(ctx context.Context, path string, data tmbytes.HexBytes) (res *ctypes.ResultABCIQuery, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.ABCIQuery(ctx, path, data)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) ABCIQueryWithOptions(ctx context.Context, path string, data tmbytes.HexBytes,
+	opts rpc.ABCIQueryOptions) (res *ctypes.ResultABCIQuery, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.ABCIQueryWithOptions(ctx, path, data, opts)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) BroadcastTxCommit(ctx context.Context, tx tmtypes.Tx) (res *ctypes.ResultBroadcastTxCommit, err error) {
+	err = p.doBroadcast(ctx, func(n *rpcNode) (e error) {
+		res, e = n.BroadcastTxCommit(ctx, tx)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) BroadcastTxAsync(ctx context.Context, tx tmtypes.Tx) (res *ctypes.ResultBroadcastTx, err error) {
+	err = p.doBroadcast(ctx, func(n *rpcNode) (e error) {
+		res, e = n.BroadcastTxAsync(ctx, tx)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) BroadcastTxSync(ctx context.Context, tx tmtypes.Tx) (res *ctypes.ResultBroadcastTx, err error) {
+	err = p.doBroadcast(ctx, func(n *rpcNode) (e error) {
+		res, e = n.BroadcastTxSync(ctx, tx)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) Block(ctx context.Context, height *int64) (res *ctypes.ResultBlock, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.Block(ctx, height)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) BlockByHash(ctx context.Context, hash []byte) (res *ctypes.ResultBlock, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.BlockByHash(ctx, hash)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) BlockResults(ctx context.Context, height *int64) (res *ctypes.ResultBlockResults, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.BlockResults(ctx, height)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) Commit(ctx context.Context, height *int64) (res *ctypes.ResultCommit, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.Commit(ctx, height)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) Validators(ctx context.Context, height *int64, page, perPage *int) (res *ctypes.ResultValidators, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.Validators(ctx, height, page, perPage)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) Tx(ctx context.Context, hash []byte, prove bool) (res *ctypes.ResultTx, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.Tx(ctx, hash, prove)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) TxSearch(ctx context.Context, query string, prove bool, page, perPage *int,
+	orderBy string) (res *ctypes.ResultTxSearch, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.TxSearch(ctx, query, prove, page, perPage, orderBy)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) BlockSearch(ctx context.Context, query string, page, perPage *int,
+	orderBy string) (res *ctypes.ResultBlockSearch, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.BlockSearch(ctx, query, page, perPage, orderBy)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) Status(ctx context.Context) (res *ctypes.ResultStatus, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.Status(ctx)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) NetInfo(ctx context.Context) (res *ctypes.ResultNetInfo, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.NetInfo(ctx)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) DumpConsensusState(ctx context.Context) (res *ctypes.ResultDumpConsensusState, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.DumpConsensusState(ctx)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) ConsensusState(ctx context.Context) (res *ctypes.ResultConsensusState, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.ConsensusState(ctx)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) ConsensusParams(ctx context.Context, height *int64) (res *ctypes.ResultConsensusParams, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.ConsensusParams(ctx, height)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) Health(ctx context.Context) (res *ctypes.ResultHealth, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.Health(ctx)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) Genesis(ctx context.Context) (res *ctypes.ResultGenesis, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.Genesis(ctx)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) GenesisChunked(ctx context.Context, id uint) (res *ctypes.ResultGenesisChunk, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.GenesisChunked(ctx, id)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) BlockchainInfo(ctx context.Context, minHeight, maxHeight int64) (res *ctypes.ResultBlockchainInfo, err error) {
+	err = p.do(ctx, func(n *rpcNode) (e error) {
+		res, e = n.BlockchainInfo(ctx, minHeight, maxHeight)
+		return
+	})
+	return
+}
+
+func (p *rpcPool) SubscribeNewBlock(builder *sdk.EventQueryBuilder, handler sdk.EventNewBlockHandler) (sdk.Subscription, sdk.Error) {
+	return p.subscribe(func(n *rpcNode) (sdk.Subscription, sdk.Error) {
+		return n.SubscribeNewBlock(builder, handler)
+	})
+}
+
+func (p *rpcPool) SubscribeTx(builder *sdk.EventQueryBuilder, handler sdk.EventTxHandler) (sdk.Subscription, sdk.Error) {
+	return p.subscribe(func(n *rpcNode) (sdk.Subscription, sdk.Error) {
+		return n.SubscribeTx(builder, handler)
+	})
+}
+
+func (p *rpcPool) SubscribeNewBlockHeader(handler sdk.EventNewBlockHeaderHandler) (sdk.Subscription, sdk.Error) {
+	return p.subscribe(func(n *rpcNode) (sdk.Subscription, sdk.Error) {
+		return n.SubscribeNewBlockHeader(handler)
+	})
+}
+
+func (p *rpcPool) SubscribeValidatorSetUpdates(handler sdk.EventValidatorSetUpdatesHandler) (sdk.Subscription, sdk.Error) {
+	return p.subscribe(func(n *rpcNode) (sdk.Subscription, sdk.Error) {
+		return n.SubscribeValidatorSetUpdates(handler)
+	})
+}
+
+// OnWSStatusChange registers the callback on the websocket of every node
+func (p *rpcPool) OnWSStatusChange(handler sdk.WSStatusHandler) {
+	for _, n := range p.nodes {
+		n.OnWSStatusChange(handler)
+	}
+}
+
+// configureSubscriptions applies the subscription settings of cfg, before any subscription
+func (p *rpcPool) configureSubscriptions(cfg sdk.ClientConfig) {
+	for _, n := range p.nodes {
+		n.ws.maxAttempts = cfg.WSMaxReconnectAttempts
+		n.ws.maxBackoff = cfg.WSMaxReconnectBackoff
+		n.subscribeOpts = cfg.SubscribeOptions
+	}
+}
+
+func (p *rpcPool) SubscribeChan(ctx context.Context, query string, opts sdk.SubscribeOptions) (sub sdk.EventSubscription, err sdk.Error) {
+	_, err = p.subscribe(func(n *rpcNode) (sdk.Subscription, sdk.Error) {
+		var e sdk.Error
+		sub, e = n.SubscribeChan(ctx, query, opts)
+		return sub.Subscription, e
+	})
+	return sub, err
+}
+
+func (p *rpcPool) SubscribeTxChan(ctx context.Context, builder *sdk.EventQueryBuilder, opts sdk.SubscribeOptions) (sub sdk.TxSubscription, err sdk.Error) {
+	_, err = p.subscribe(func(n *rpcNode) (sdk.Subscription, sdk.Error) {
+		var e sdk.Error
+		sub, e = n.SubscribeTxChan(ctx, builder, opts)
+		return sub.Subscription, e
+	})
+	return sub, err
+}
+
+func (p *rpcPool) SubscribeNewBlockChan(ctx context.Context, builder *sdk.EventQueryBuilder, opts sdk.SubscribeOptions) (sub sdk.BlockSubscription, err sdk.Error) {
+	_, err = p.subscribe(func(n *rpcNode) (sdk.Subscription, sdk.Error) {
+		var e sdk.Error
+		sub, e = n.SubscribeNewBlockChan(ctx, builder, opts)
+		return sub.Subscription, e
+	})
+	return sub, err
+}
+
+func (p *rpcPool) Unsubscribe(subscription sdk.Subscription) sdk.Error {
+	return p.UnsubscribeContext(context.Background(), subscription)
+}
+
+// UnsubscribeContext sends the unsubscription to the node holding the subscription
+func (p *rpcPool) UnsubscribeContext(ctx context.Context, subscription sdk.Subscription) sdk.Error {
+	for _, n := range p.nodes {
+		if n.ws.hasSubscription(subscription.ID) {
+			return n.UnsubscribeContext(ctx, subscription)
+		}
+	}
+	return sdk.Wrapf("subscription %s not found", subscription.ID)
+}
+
+// subscribe creates the subscription on the first node that accepts it
+func (p *rpcPool) subscribe(fn func(n *rpcNode) (sdk.Subscription, sdk.Error)) (subscription sdk.Subscription, err sdk.Error) {
+	for _, n := range p.candidates() {
+		if subscription, err = fn(n); err == nil {
+			return subscription, nil
+		}
+		p.Error("subscribe failed, trying the next node", "node", n.url, "errMsg", err.Error())
+	}
+	return subscription, err
+}
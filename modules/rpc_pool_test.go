@@ -0,0 +1,118 @@
+package modules
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/p2p"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+
+	"plugchain-sdk-go/utils/rpctest"
+)
+
+// newStubNode starts a tendermint rpc server answering the status method only
+func newStubNode(t *testing.T, network string, catchingUp bool) *httptest.Server {
+	return rpctest.NewNode(t, map[string]func(rpctypes.RPCRequest) rpctypes.RPCResponse{
+		"status": rpctest.Result(&ctypes.ResultStatus{
+			NodeInfo: p2p.DefaultNodeInfo{Network: network},
+			SyncInfo: ctypes.SyncInfo{CatchingUp: catchingUp},
+		}),
+	})
+}
+
+func TestRPCPool(t *testing.T) {
+	healthy := newStubNode(t, "chaintest-1", false)
+	catchingUp := newStubNode(t, "chaintest-1", true)
+	wrongChain := newStubNode(t, "chaintest-2", false)
+	down := newStubNode(t, "chaintest-1", false)
+	down.Close()
+
+	remotes := []string{catchingUp.URL, wrongChain.URL, down.URL, healthy.URL}
+	pool, err := NewRPCPool(remotes, "chaintest-1", nil, nil, log.NewNopLogger(), 1, 0)
+	require.NoError(t, err)
+	defer pool.Stop()
+
+	var health []bool
+	pool.OnHealthChange(func(h []bool) { health = h })
+	require.Equal(t, []bool{false, false, false, true}, health)
+
+	for i := 0; i < len(remotes); i++ {
+		require.Equal(t, healthy.URL, pool.candidates()[0].url)
+	}
+
+	status, err := pool.Status(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, "chaintest-1", status.NodeInfo.Network)
+
+	// rpc errors returned by a node are not retried on the other nodes
+	_, err = pool.ABCIInfo(context.Background())
+	require.Error(t, err)
+	require.True(t, pool.nodes[3].isHealthy())
+}
+
+func TestRPCPoolFailover(t *testing.T) {
+	node1 := newStubNode(t, "chaintest-1", false)
+	node2 := newStubNode(t, "chaintest-1", false)
+
+	pool, err := NewRPCPool([]string{node1.URL, node2.URL}, "chaintest-1", nil, nil, log.NewNopLogger(), 1, 0)
+	require.NoError(t, err)
+	defer pool.Stop()
+	require.Equal(t, []bool{true, true}, pool.health())
+
+	node1.Close()
+	for i := 0; i < 4; i++ {
+		_, err := pool.Status(context.Background())
+		require.NoError(t, err)
+	}
+	require.Equal(t, []bool{false, true}, pool.health())
+
+	node2.Close()
+	_, err = pool.Status(context.Background())
+	require.Error(t, err)
+}
+
+func TestRPCPoolBroadcast(t *testing.T) {
+	// the nodes receive the txs but their responses are broken, the connections are not
+	// kept alive so that a node closed can't be reached
+	var broadcasts int32
+	handler := rpctest.NewHandler(map[string]func(rpctypes.RPCRequest) rpctypes.RPCResponse{
+		"status": rpctest.Result(&ctypes.ResultStatus{NodeInfo: p2p.DefaultNodeInfo{Network: "chaintest-1"}}),
+		"broadcast_tx_sync": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			atomic.AddInt32(&broadcasts, 1)
+			return rpctypes.NewRPCSuccessResponse(req.ID, "broken")
+		},
+	})
+	newNode := func() *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Connection", "close")
+			handler.ServeHTTP(w, r)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	node1, node2 := newNode(), newNode()
+
+	pool, err := NewRPCPool([]string{node1.URL, node2.URL}, "chaintest-1", nil, nil, log.NewNopLogger(), 1, 0)
+	require.NoError(t, err)
+	defer pool.Stop()
+
+	// a tx which may have been received is not sent to the other node
+	_, err = pool.BroadcastTxSync(context.Background(), []byte("tx"))
+	require.Error(t, err)
+	require.Equal(t, int32(1), atomic.LoadInt32(&broadcasts))
+
+	// a tx which could not be sent is
+	node1.Close()
+	for i := 0; i < 2; i++ {
+		_, err = pool.BroadcastTxSync(context.Background(), []byte("tx"))
+		require.Error(t, err)
+	}
+	require.Equal(t, int32(3), atomic.LoadInt32(&broadcasts))
+	require.Equal(t, []bool{false, true}, pool.health())
+}
@@ -0,0 +1,114 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strconv"
+	"sync"
+
+	sdk "plugchain-sdk-go/types"
+)
+
+// sequenceMismatchRegexp extracts the sequences from the log of sdk.ErrWrongSequence returned by
+// the ante handler, e.g. "account sequence mismatch, expected 12, got 10"
+var sequenceMismatchRegexp = regexp.MustCompile(`account sequence mismatch, expected (\d+), got (\d+)`)
+
+// sequenceManager hands out the account sequences locally, so that several txs of the
+// same account can be in the mempool at the same time without querying the chain.
+type sequenceManager struct {
+	mtx      sync.Mutex
+	accounts map[string]*accountSequence
+}
+
+// accountSequence tracks the next sequence of an account, it must be locked while
+// a tx using the sequence is signed and broadcast
+type accountSequence struct {
+	sync.Mutex
+	address       string
+	accountNumber uint64
+	sequence      uint64
+	loaded        bool
+}
+
+func newSequenceManager() *sequenceManager {
+	return &sequenceManager{
+		accounts: make(map[string]*accountSequence),
+	}
+}
+
+// acquire locks the sequence of the address, loading it from the chain if it is unknown.
+// The caller must call Unlock when the tx is broadcast.
+func (m *sequenceManager) acquire(ctx context.Context, address string,
+	load func(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error)) (*accountSequence, sdk.Error) {
+	m.mtx.Lock()
+	seq, ok := m.accounts[address]
+	if !ok {
+		seq = &accountSequence{address: address}
+		m.accounts[address] = seq
+	}
+	m.mtx.Unlock()
+
+	seq.Lock()
+	if !seq.loaded {
+		account, err := load(ctx, address)
+		if err != nil {
+			seq.Unlock()
+			return nil, err
+		}
+		seq.accountNumber = account.AccountNumber
+		seq.sequence = account.Sequence
+		seq.loaded = true
+	}
+	return seq, nil
+}
+
+// update records the result of broadcasting a tx signed with the current sequence and
+// returns whether the tx should be signed again, which is the case when the node
+// reported a sequence mismatch
+func (s *accountSequence) update(err sdk.Error, simulate bool) bool {
+	if err == nil {
+		if !simulate {
+			s.sequence++
+		}
+		return false
+	}
+
+	if expected, got, ok := parseSequenceMismatch(err); ok {
+		// the mismatch is about another signer of the tx, e.g. the fee payer, and
+		// the sequence was not consumed as the tx was rejected by CheckTx
+		if got != s.sequence {
+			return false
+		}
+		s.sequence = expected
+		return true
+	}
+
+	// it is unknown whether the sequence was consumed, e.g. the tx failed in DeliverTx
+	// or the broadcast timed out, so the sequence is reloaded before the next tx
+	s.loaded = false
+	return false
+}
+
+// parseSequenceMismatch returns the sequence expected by the node and the sequence of the tx
+// from a sequence mismatch error
+func parseSequenceMismatch(err error) (expected, got uint64, ok bool) {
+	if !errors.Is(err, sdk.ErrWrongSequence) {
+		return 0, 0, false
+	}
+
+	matches := sequenceMismatchRegexp.FindStringSubmatch(err.Error())
+	if len(matches) != 3 {
+		return 0, 0, false
+	}
+
+	expected, e := strconv.ParseUint(matches[1], 10, 64)
+	if e != nil {
+		return 0, 0, false
+	}
+	got, e = strconv.ParseUint(matches[2], 10, 64)
+	if e != nil {
+		return 0, 0, false
+	}
+	return expected, got, true
+}
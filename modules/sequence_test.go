@@ -0,0 +1,90 @@
+package modules
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "plugchain-sdk-go/types"
+)
+
+func TestSequenceManager(t *testing.T) {
+	var loads int
+	load := func(ctx context.Context, address string) (sdk.BaseAccount, sdk.Error) {
+		loads++
+		return sdk.BaseAccount{Address: address, AccountNumber: 7, Sequence: 10}, nil
+	}
+
+	m := newSequenceManager()
+	var used []uint64
+	var errs []error
+	var mtx sync.Mutex
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			seq, err := m.acquire(context.Background(), "addr", load)
+			mtx.Lock()
+			defer mtx.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+				return
+			}
+			defer seq.Unlock()
+
+			used = append(used, seq.sequence)
+			if seq.update(nil, false) {
+				errs = append(errs, fmt.Errorf("unexpected retry of sequence %d", seq.sequence))
+			}
+		}()
+	}
+	wg.Wait()
+	require.Empty(t, errs)
+	require.Equal(t, 1, loads)
+	require.Len(t, used, 50)
+	require.ElementsMatch(t, used, sequences(10, 60))
+
+	seq, err := m.acquire(context.Background(), "addr", load)
+	require.Nil(t, err)
+	require.Equal(t, uint64(60), seq.sequence)
+
+	// the sequence expected by the node is used for the retry
+	mismatch := sdk.GetError(sdk.RootCodespace, uint32(sdk.WrongSequence), "account sequence mismatch, expected 58, got 60: incorrect account sequence")
+	require.True(t, seq.update(mismatch, false))
+	require.Equal(t, uint64(58), seq.sequence)
+
+	// a mismatch of the fee payer leaves the sequence of the signer unchanged
+	mismatch = sdk.GetError(sdk.RootCodespace, uint32(sdk.WrongSequence), "account sequence mismatch, expected 4, got 3: incorrect account sequence")
+	require.False(t, seq.update(mismatch, false))
+	require.Equal(t, uint64(58), seq.sequence)
+
+	// only the code of the error is trusted, not its log
+	require.False(t, seq.update(sdk.Wrapf("account sequence mismatch, expected 58, got 58"), false))
+	seq.Unlock()
+
+	seq, err = m.acquire(context.Background(), "addr", load)
+	require.Nil(t, err)
+	require.Equal(t, 2, loads)
+	require.Equal(t, uint64(10), seq.sequence)
+
+	// any other failure reloads the sequence before the next tx
+	require.False(t, seq.update(sdk.Wrapf("insufficient funds"), false))
+	seq.Unlock()
+
+	seq, err = m.acquire(context.Background(), "addr", load)
+	require.Nil(t, err)
+	require.Equal(t, 3, loads)
+	require.Equal(t, uint64(10), seq.sequence)
+	seq.Unlock()
+}
+
+func sequences(from, to uint64) (seqs []uint64) {
+	for seq := from; seq < to; seq++ {
+		seqs = append(seqs, seq)
+	}
+	return seqs
+}
@@ -0,0 +1,60 @@
+package staking
+
+import (
+	"time"
+
+	sdk "plugchain-sdk-go/types"
+)
+
+// CreateValidatorEvent is emitted when a validator is created with its self delegation
+type CreateValidatorEvent struct {
+	Validator string  `event:"validator"`
+	Amount    sdk.Int `event:"amount"`
+}
+
+func (CreateValidatorEvent) EventType() string { return "create_validator" }
+
+// DelegateEvent is emitted when tokens are delegated to a validator
+type DelegateEvent struct {
+	Validator string  `event:"validator"`
+	Amount    sdk.Int `event:"amount"`
+	NewShares sdk.Dec `event:"new_shares"`
+}
+
+func (DelegateEvent) EventType() string { return "delegate" }
+
+// UnbondEvent is emitted when tokens start unbonding from a validator
+type UnbondEvent struct {
+	Validator      string    `event:"validator"`
+	Amount         sdk.Int   `event:"amount"`
+	CompletionTime time.Time `event:"completion_time"`
+}
+
+func (UnbondEvent) EventType() string { return "unbond" }
+
+// RedelegateEvent is emitted when tokens start moving from a validator to another
+type RedelegateEvent struct {
+	SourceValidator      string    `event:"source_validator"`
+	DestinationValidator string    `event:"destination_validator"`
+	Amount               sdk.Int   `event:"amount"`
+	CompletionTime       time.Time `event:"completion_time"`
+}
+
+func (RedelegateEvent) EventType() string { return "redelegate" }
+
+// CompleteUnbondingEvent is emitted when the unbonded tokens are returned to the delegator
+type CompleteUnbondingEvent struct {
+	Amount    sdk.Coins `event:"amount"`
+	Validator string    `event:"validator"`
+	Delegator string    `event:"delegator"`
+}
+
+func (CompleteUnbondingEvent) EventType() string { return "complete_unbonding" }
+
+func init() {
+	sdk.RegisterEvent(CreateValidatorEvent{})
+	sdk.RegisterEvent(DelegateEvent{})
+	sdk.RegisterEvent(UnbondEvent{})
+	sdk.RegisterEvent(RedelegateEvent{})
+	sdk.RegisterEvent(CompleteUnbondingEvent{})
+}
@@ -1,6 +1,8 @@
 package staking
 
 import (
+	"context"
+
 	"time"
 
 	sdk "plugchain-sdk-go/types"
@@ -16,6 +18,13 @@ type Client interface {
 	Undelegate(request UndelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 	BeginRedelegate(request BeginRedelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 
+	// the msgs of the above methods, to be sent together with the msgs of other modules
+	CreateValidatorMsg(delegator string, request CreateValidatorRequest) (sdk.Msg, sdk.Error)
+	EditValidatorMsg(delegator string, request EditValidatorRequest) (sdk.Msg, sdk.Error)
+	DelegateMsg(delegator string, request DelegateRequest) (sdk.Msg, sdk.Error)
+	UndelegateMsg(delegator string, request UndelegateRequest) (sdk.Msg, sdk.Error)
+	BeginRedelegateMsg(delegator string, request BeginRedelegateRequest) (sdk.Msg, sdk.Error)
+
 	QueryValidators(status string, page, size uint64) (QueryValidatorsResp, sdk.Error)
 	QueryValidator(validatorAddr string) (QueryValidatorResp, sdk.Error)
 	QueryValidatorDelegations(validatorAddr string, page, size uint64) (QueryValidatorDelegationsResp, sdk.Error)
@@ -30,6 +39,39 @@ type Client interface {
 	QueryHistoricalInfo(height int64) (QueryHistoricalInfoResp, sdk.Error)
 	QueryPool() (QueryPoolResp, sdk.Error)
 	QueryParams() (QueryParamsResp, sdk.Error)
+
+	CreateValidatorContext(ctx context.Context, request CreateValidatorRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	EditValidatorContext(ctx context.Context, request EditValidatorRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	DelegateContext(ctx context.Context, request DelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	UndelegateContext(ctx context.Context, request UndelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	BeginRedelegateContext(ctx context.Context, request BeginRedelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	CreateValidatorMsgContext(ctx context.Context, delegator string, request CreateValidatorRequest) (sdk.Msg, sdk.Error)
+	DelegateMsgContext(ctx context.Context, delegator string, request DelegateRequest) (sdk.Msg, sdk.Error)
+	UndelegateMsgContext(ctx context.Context, delegator string, request UndelegateRequest) (sdk.Msg, sdk.Error)
+	BeginRedelegateMsgContext(ctx context.Context, delegator string, request BeginRedelegateRequest) (sdk.Msg, sdk.Error)
+	QueryValidatorsContext(ctx context.Context, status string, page, size uint64) (QueryValidatorsResp, sdk.Error)
+	QueryValidatorContext(ctx context.Context, validatorAddr string) (QueryValidatorResp, sdk.Error)
+	QueryValidatorDelegationsContext(ctx context.Context, validatorAddr string, page, size uint64) (QueryValidatorDelegationsResp, sdk.Error)
+	QueryValidatorUnbondingDelegationsContext(ctx context.Context, validatorAddr string, page, size uint64) (QueryValidatorUnbondingDelegationsResp, sdk.Error)
+	QueryDelegationContext(ctx context.Context, delegatorAddr string, validatorAddr string) (QueryDelegationResp, sdk.Error)
+	QueryUnbondingDelegationContext(ctx context.Context, delegatorAddr string, validatorAddr string) (QueryUnbondingDelegationResp, sdk.Error)
+	QueryDelegatorDelegationsContext(ctx context.Context, delegatorAddr string, page, size uint64) (QueryDelegatorDelegationsResp, sdk.Error)
+	QueryDelegatorUnbondingDelegationsContext(ctx context.Context, delegatorAddr string, page, size uint64) (QueryDelegatorUnbondingDelegationsResp, sdk.Error)
+	QueryRedelegationsContext(ctx context.Context, request QueryRedelegationsReq) (QueryRedelegationsResp, sdk.Error)
+	QueryDelegatorValidatorsContext(ctx context.Context, delegatorAddr string, page, size uint64) (QueryDelegatorValidatorsResp, sdk.Error)
+	QueryDelegatorValidatorContext(ctx context.Context, delegatorAddr string, validatorAddr string) (QueryValidatorResp, sdk.Error)
+	QueryHistoricalInfoContext(ctx context.Context, height int64) (QueryHistoricalInfoResp, sdk.Error)
+	QueryPoolContext(ctx context.Context) (QueryPoolResp, sdk.Error)
+	QueryParamsContext(ctx context.Context) (QueryParamsResp, sdk.Error)
+
+	// the list queries walking every page, see sdk.PageIterator
+	IterateValidators(ctx context.Context, status string, size uint64) ValidatorIterator
+	IterateValidatorDelegations(ctx context.Context, validatorAddr string, size uint64) DelegationIterator
+	IterateValidatorUnbondingDelegations(ctx context.Context, validatorAddr string, size uint64) UnbondingDelegationIterator
+	IterateDelegatorDelegations(ctx context.Context, delegatorAddr string, size uint64) DelegationIterator
+	IterateDelegatorUnbondingDelegations(ctx context.Context, delegatorAddr string, size uint64) UnbondingDelegationIterator
+	IterateRedelegations(ctx context.Context, request QueryRedelegationsReq) RedelegationIterator
+	IterateDelegatorValidators(ctx context.Context, delegatorAddr string, size uint64) ValidatorIterator
 }
 
 type CreateValidatorRequest struct {
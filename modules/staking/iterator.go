@@ -0,0 +1,220 @@
+package staking
+
+import (
+	"context"
+
+	sdk "plugchain-sdk-go/types"
+	"plugchain-sdk-go/types/query"
+)
+
+// ValidatorIterator walks the validators of every page
+type ValidatorIterator struct {
+	*sdk.PageIterator
+}
+
+func (it ValidatorIterator) Validator() QueryValidatorResp {
+	return it.Item().(QueryValidatorResp)
+}
+
+// All returns the validators of the pages left
+func (it ValidatorIterator) All() ([]QueryValidatorResp, sdk.Error) {
+	var validators []QueryValidatorResp
+	for it.Next() {
+		validators = append(validators, it.Validator())
+	}
+	return validators, it.Err()
+}
+
+// DelegationIterator walks the delegations of every page
+type DelegationIterator struct {
+	*sdk.PageIterator
+}
+
+func (it DelegationIterator) Delegation() QueryDelegationResp {
+	return it.Item().(QueryDelegationResp)
+}
+
+// All returns the delegations of the pages left
+func (it DelegationIterator) All() ([]QueryDelegationResp, sdk.Error) {
+	var delegations []QueryDelegationResp
+	for it.Next() {
+		delegations = append(delegations, it.Delegation())
+	}
+	return delegations, it.Err()
+}
+
+// UnbondingDelegationIterator walks the unbonding delegations of every page
+type UnbondingDelegationIterator struct {
+	*sdk.PageIterator
+}
+
+func (it UnbondingDelegationIterator) UnbondingDelegation() QueryUnbondingDelegationResp {
+	return it.Item().(QueryUnbondingDelegationResp)
+}
+
+// All returns the unbonding delegations of the pages left
+func (it UnbondingDelegationIterator) All() ([]QueryUnbondingDelegationResp, sdk.Error) {
+	var unbondingDelegations []QueryUnbondingDelegationResp
+	for it.Next() {
+		unbondingDelegations = append(unbondingDelegations, it.UnbondingDelegation())
+	}
+	return unbondingDelegations, it.Err()
+}
+
+// RedelegationIterator walks the redelegations of every page
+type RedelegationIterator struct {
+	*sdk.PageIterator
+}
+
+func (it RedelegationIterator) Redelegation() RedelegationResp {
+	return it.Item().(RedelegationResp)
+}
+
+// All returns the redelegations of the pages left
+func (it RedelegationIterator) All() ([]RedelegationResp, sdk.Error) {
+	var redelegations []RedelegationResp
+	for it.Next() {
+		redelegations = append(redelegations, it.Redelegation())
+	}
+	return redelegations, it.Err()
+}
+
+// IterateValidators walks the validators by pages of size, all status' validators when status is ""
+func (sc stakingClient) IterateValidators(ctx context.Context, status string, size uint64) ValidatorIterator {
+	return ValidatorIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).Validators(ctx, &QueryValidatorsRequest{Status: status, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Validators))
+		for i, v := range res.Validators {
+			items[i] = v.Convert(sc.Marshaler)
+		}
+		return items, res.Pagination, nil
+	})}
+}
+
+func (sc stakingClient) IterateValidatorDelegations(ctx context.Context, validatorAddr string, size uint64) DelegationIterator {
+	return DelegationIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).ValidatorDelegations(ctx, &QueryValidatorDelegationsRequest{ValidatorAddr: validatorAddr, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.DelegationResponses))
+		for i, v := range res.DelegationResponses {
+			items[i] = v.Convert()
+		}
+		return items, res.Pagination, nil
+	})}
+}
+
+func (sc stakingClient) IterateValidatorUnbondingDelegations(ctx context.Context, validatorAddr string, size uint64) UnbondingDelegationIterator {
+	return UnbondingDelegationIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).ValidatorUnbondingDelegations(ctx, &QueryValidatorUnbondingDelegationsRequest{ValidatorAddr: validatorAddr, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.UnbondingResponses))
+		for i, v := range res.UnbondingResponses {
+			items[i] = v.Convert()
+		}
+		return items, res.Pagination, nil
+	})}
+}
+
+func (sc stakingClient) IterateDelegatorDelegations(ctx context.Context, delegatorAddr string, size uint64) DelegationIterator {
+	return DelegationIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).DelegatorDelegations(ctx, &QueryDelegatorDelegationsRequest{DelegatorAddr: delegatorAddr, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.DelegationResponses))
+		for i, v := range res.DelegationResponses {
+			items[i] = v.Convert()
+		}
+		return items, res.Pagination, nil
+	})}
+}
+
+func (sc stakingClient) IterateDelegatorUnbondingDelegations(ctx context.Context, delegatorAddr string, size uint64) UnbondingDelegationIterator {
+	return UnbondingDelegationIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).DelegatorUnbondingDelegations(ctx, &QueryDelegatorUnbondingDelegationsRequest{DelegatorAddr: delegatorAddr, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.UnbondingResponses))
+		for i, v := range res.UnbondingResponses {
+			items[i] = v.Convert()
+		}
+		return items, res.Pagination, nil
+	})}
+}
+
+// IterateRedelegations walks the redelegations by pages of request.Size, request.Page is ignored
+func (sc stakingClient) IterateRedelegations(ctx context.Context, request QueryRedelegationsReq) RedelegationIterator {
+	return RedelegationIterator{sdk.NewPageIterator(ctx, request.Size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).Redelegations(ctx, &QueryRedelegationsRequest{
+			DelegatorAddr:    request.DelegatorAddr,
+			SrcValidatorAddr: request.SrcValidatorAddr,
+			DstValidatorAddr: request.DstValidatorAddr,
+			Pagination:       page,
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.RedelegationResponses))
+		for i, v := range res.RedelegationResponses {
+			items[i] = v.Convert()
+		}
+		return items, res.Pagination, nil
+	})}
+}
+
+func (sc stakingClient) IterateDelegatorValidators(ctx context.Context, delegatorAddr string, size uint64) ValidatorIterator {
+	return ValidatorIterator{sdk.NewPageIterator(ctx, size, func(ctx context.Context, page *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		conn, err := sc.GenConn()
+		if err != nil {
+			return nil, nil, err
+		}
+
+		res, err := NewQueryClient(conn).DelegatorValidators(ctx, &QueryDelegatorValidatorsRequest{DelegatorAddr: delegatorAddr, Pagination: page})
+		if err != nil {
+			return nil, nil, err
+		}
+		items := make([]interface{}, len(res.Validators))
+		for i, v := range res.Validators {
+			items[i] = v.Convert(sc.Marshaler)
+		}
+		return items, res.Pagination, nil
+	})}
+}
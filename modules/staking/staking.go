@@ -30,31 +30,53 @@ func (sc stakingClient) RegisterInterfaceTypes(registry types.InterfaceRegistry)
 	RegisterInterfaces(registry)
 }
 
+func (sc stakingClient) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	RegisterLegacyAminoCodec(cdc)
+}
+
 func (sc stakingClient) CreateValidator(request CreateValidatorRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return sc.CreateValidatorContext(context.Background(), request, baseTx)
+}
+
+func (sc stakingClient) CreateValidatorContext(ctx context.Context, request CreateValidatorRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	delegatorAddr, err := sc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
-	valAddr, err := sdk.ValAddressFromBech32(delegatorAddr.String())
+
+	msg, err := sc.CreateValidatorMsgContext(ctx, delegatorAddr.String(), request)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return sdk.ResultTx{}, err
 	}
+	return sc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// CreateValidatorMsg returns the msg of CreateValidator for the account `delegator`
+func (sc stakingClient) CreateValidatorMsg(delegator string, request CreateValidatorRequest) (sdk.Msg, sdk.Error) {
+	return sc.CreateValidatorMsgContext(context.Background(), delegator, request)
+}
 
-	values, err := sc.ToMinCoin(request.Value)
+func (sc stakingClient) CreateValidatorMsgContext(ctx context.Context, delegator string, request CreateValidatorRequest) (sdk.Msg, sdk.Error) {
+	valAddr, err := sdk.ValAddressFromBech32(delegator)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return nil, sdk.Wrap(err)
+	}
+
+	values, err := sc.ToMinCoinContext(ctx, request.Value)
+	if err != nil {
+		return nil, sdk.Wrap(err)
 	}
 
 	pk, e := sdk.GetPubKeyFromBech32(sdk.Bech32PubKeyTypeConsPub, request.Pubkey)
 	if e != nil {
-		return sdk.ResultTx{}, sdk.Wrap(e)
+		return nil, sdk.Wrap(e)
 	}
 	pkAny, e := types.PackAny(pk)
 	if e != nil {
-		return sdk.ResultTx{}, sdk.Wrap(e)
+		return nil, sdk.Wrap(e)
 	}
 
-	msg := &MsgCreateValidator{
+	return &MsgCreateValidator{
 		Description: Description{
 			Moniker: request.Moniker,
 		},
@@ -64,25 +86,38 @@ func (sc stakingClient) CreateValidator(request CreateValidatorRequest, baseTx s
 			MaxChangeRate: request.MaxChangeRate,
 		},
 		MinSelfDelegation: request.MinSelfDelegation,
-		DelegatorAddress:  delegatorAddr.String(),
+		DelegatorAddress:  delegator,
 		ValidatorAddress:  valAddr.String(),
 		Pubkey:            pkAny,
 		Value:             values[0],
-	}
-	return sc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 func (sc stakingClient) EditValidator(request EditValidatorRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return sc.EditValidatorContext(context.Background(), request, baseTx)
+}
+
+func (sc stakingClient) EditValidatorContext(ctx context.Context, request EditValidatorRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	delegatorAddr, err := sc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
-	valAddr, err := sdk.ValAddressFromBech32(delegatorAddr.String())
+
+	msg, err := sc.EditValidatorMsg(delegatorAddr.String(), request)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return sdk.ResultTx{}, err
+	}
+	return sc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// EditValidatorMsg returns the msg of EditValidator for the validator of the account `delegator`
+func (sc stakingClient) EditValidatorMsg(delegator string, request EditValidatorRequest) (sdk.Msg, sdk.Error) {
+	valAddr, err := sdk.ValAddressFromBech32(delegator)
+	if err != nil {
+		return nil, sdk.Wrap(err)
 	}
 
-	msg := &MsgEditValidator{
+	return &MsgEditValidator{
 		Description: Description{
 			Moniker:         request.Moniker,
 			Identity:        request.Identity,
@@ -93,78 +128,130 @@ func (sc stakingClient) EditValidator(request EditValidatorRequest, baseTx sdk.B
 		ValidatorAddress:  valAddr.String(),
 		CommissionRate:    &request.CommissionRate,
 		MinSelfDelegation: &request.MinSelfDelegation,
-	}
-	return sc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 func (sc stakingClient) Delegate(request DelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return sc.DelegateContext(context.Background(), request, baseTx)
+}
+
+func (sc stakingClient) DelegateContext(ctx context.Context, request DelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	delegatorAddr, err := sc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	coins, err := sc.ToMinCoin(request.Amount)
+	msg, err := sc.DelegateMsgContext(ctx, delegatorAddr.String(), request)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return sdk.ResultTx{}, err
+	}
+	return sc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// DelegateMsg returns the msg of Delegate for the account `delegator`
+func (sc stakingClient) DelegateMsg(delegator string, request DelegateRequest) (sdk.Msg, sdk.Error) {
+	return sc.DelegateMsgContext(context.Background(), delegator, request)
+}
+
+func (sc stakingClient) DelegateMsgContext(ctx context.Context, delegator string, request DelegateRequest) (sdk.Msg, sdk.Error) {
+	coins, err := sc.ToMinCoinContext(ctx, request.Amount)
+	if err != nil {
+		return nil, sdk.Wrap(err)
 	}
 
-	msg := &MsgDelegate{
-		DelegatorAddress: delegatorAddr.String(),
+	return &MsgDelegate{
+		DelegatorAddress: delegator,
 		ValidatorAddress: request.ValidatorAddr,
 		Amount:           coins[0],
-	}
-	return sc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 func (sc stakingClient) Undelegate(request UndelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return sc.UndelegateContext(context.Background(), request, baseTx)
+}
+
+func (sc stakingClient) UndelegateContext(ctx context.Context, request UndelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	delegatorAddr, err := sc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	coins, err := sc.ToMinCoin(request.Amount)
+	msg, err := sc.UndelegateMsgContext(ctx, delegatorAddr.String(), request)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return sdk.ResultTx{}, err
+	}
+	return sc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// UndelegateMsg returns the msg of Undelegate for the account `delegator`
+func (sc stakingClient) UndelegateMsg(delegator string, request UndelegateRequest) (sdk.Msg, sdk.Error) {
+	return sc.UndelegateMsgContext(context.Background(), delegator, request)
+}
+
+func (sc stakingClient) UndelegateMsgContext(ctx context.Context, delegator string, request UndelegateRequest) (sdk.Msg, sdk.Error) {
+	coins, err := sc.ToMinCoinContext(ctx, request.Amount)
+	if err != nil {
+		return nil, sdk.Wrap(err)
 	}
-	msg := &MsgUndelegate{
-		DelegatorAddress: delegatorAddr.String(),
+
+	return &MsgUndelegate{
+		DelegatorAddress: delegator,
 		ValidatorAddress: request.ValidatorAddr,
 		Amount:           coins[0],
-	}
-	return sc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 func (sc stakingClient) BeginRedelegate(request BeginRedelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return sc.BeginRedelegateContext(context.Background(), request, baseTx)
+}
+
+func (sc stakingClient) BeginRedelegateContext(ctx context.Context, request BeginRedelegateRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	delegatorAddr, err := sc.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	coins, err := sc.ToMinCoin(request.Amount)
+	msg, err := sc.BeginRedelegateMsgContext(ctx, delegatorAddr.String(), request)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return sdk.ResultTx{}, err
+	}
+	return sc.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// BeginRedelegateMsg returns the msg of BeginRedelegate for the account `delegator`
+func (sc stakingClient) BeginRedelegateMsg(delegator string, request BeginRedelegateRequest) (sdk.Msg, sdk.Error) {
+	return sc.BeginRedelegateMsgContext(context.Background(), delegator, request)
+}
+
+func (sc stakingClient) BeginRedelegateMsgContext(ctx context.Context, delegator string, request BeginRedelegateRequest) (sdk.Msg, sdk.Error) {
+	coins, err := sc.ToMinCoinContext(ctx, request.Amount)
+	if err != nil {
+		return nil, sdk.Wrap(err)
 	}
-	msg := &MsgBeginRedelegate{
-		DelegatorAddress:    delegatorAddr.String(),
+
+	return &MsgBeginRedelegate{
+		DelegatorAddress:    delegator,
 		ValidatorSrcAddress: request.ValidatorSrcAddress,
 		ValidatorDstAddress: request.ValidatorDstAddress,
 		Amount:              coins[0],
-	}
-	return sc.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 // QueryValidators when status is "" will return all status' validator
 // about status, you can see BondStatus_value
 func (sc stakingClient) QueryValidators(status string, page, size uint64) (QueryValidatorsResp, sdk.Error) {
+	return sc.QueryValidatorsContext(context.Background(), status, page, size)
+}
+
+func (sc stakingClient) QueryValidatorsContext(ctx context.Context, status string, page, size uint64) (QueryValidatorsResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryValidatorsResp{}, sdk.Wrap(err)
 	}
 
 	offset, limit := utils.ParsePage(page, size)
 	res, err := NewQueryClient(conn).Validators(
-		context.Background(),
+		ctx,
 		&QueryValidatorsRequest{
 			Status: status,
 			Pagination: &query.PageRequest{
@@ -181,14 +268,17 @@ func (sc stakingClient) QueryValidators(status string, page, size uint64) (Query
 }
 
 func (sc stakingClient) QueryValidator(validatorAddr string) (QueryValidatorResp, sdk.Error) {
+	return sc.QueryValidatorContext(context.Background(), validatorAddr)
+}
+
+func (sc stakingClient) QueryValidatorContext(ctx context.Context, validatorAddr string) (QueryValidatorResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryValidatorResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Validator(
-		context.Background(),
+		ctx,
 		&QueryValidatorRequest{
 			ValidatorAddr: validatorAddr,
 		},
@@ -200,15 +290,18 @@ func (sc stakingClient) QueryValidator(validatorAddr string) (QueryValidatorResp
 }
 
 func (sc stakingClient) QueryValidatorDelegations(validatorAddr string, page, size uint64) (QueryValidatorDelegationsResp, sdk.Error) {
+	return sc.QueryValidatorDelegationsContext(context.Background(), validatorAddr, page, size)
+}
+
+func (sc stakingClient) QueryValidatorDelegationsContext(ctx context.Context, validatorAddr string, page, size uint64) (QueryValidatorDelegationsResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryValidatorDelegationsResp{}, sdk.Wrap(err)
 	}
 
 	offset, limit := utils.ParsePage(page, size)
 	res, err := NewQueryClient(conn).ValidatorDelegations(
-		context.Background(),
+		ctx,
 		&QueryValidatorDelegationsRequest{
 			ValidatorAddr: validatorAddr,
 			Pagination: &query.PageRequest{
@@ -225,15 +318,18 @@ func (sc stakingClient) QueryValidatorDelegations(validatorAddr string, page, si
 }
 
 func (sc stakingClient) QueryValidatorUnbondingDelegations(validatorAddr string, page, size uint64) (QueryValidatorUnbondingDelegationsResp, sdk.Error) {
+	return sc.QueryValidatorUnbondingDelegationsContext(context.Background(), validatorAddr, page, size)
+}
+
+func (sc stakingClient) QueryValidatorUnbondingDelegationsContext(ctx context.Context, validatorAddr string, page, size uint64) (QueryValidatorUnbondingDelegationsResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryValidatorUnbondingDelegationsResp{}, sdk.Wrap(err)
 	}
 
 	offset, limit := utils.ParsePage(page, size)
 	res, err := NewQueryClient(conn).ValidatorUnbondingDelegations(
-		context.Background(),
+		ctx,
 		&QueryValidatorUnbondingDelegationsRequest{
 			ValidatorAddr: validatorAddr,
 			Pagination: &query.PageRequest{
@@ -250,14 +346,17 @@ func (sc stakingClient) QueryValidatorUnbondingDelegations(validatorAddr string,
 }
 
 func (sc stakingClient) QueryDelegation(delegatorAddr string, validatorAddr string) (QueryDelegationResp, sdk.Error) {
+	return sc.QueryDelegationContext(context.Background(), delegatorAddr, validatorAddr)
+}
+
+func (sc stakingClient) QueryDelegationContext(ctx context.Context, delegatorAddr string, validatorAddr string) (QueryDelegationResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryDelegationResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Delegation(
-		context.Background(),
+		ctx,
 		&QueryDelegationRequest{
 			DelegatorAddr: delegatorAddr,
 			ValidatorAddr: validatorAddr,
@@ -270,14 +369,17 @@ func (sc stakingClient) QueryDelegation(delegatorAddr string, validatorAddr stri
 }
 
 func (sc stakingClient) QueryUnbondingDelegation(delegatorAddr string, validatorAddr string) (QueryUnbondingDelegationResp, sdk.Error) {
+	return sc.QueryUnbondingDelegationContext(context.Background(), delegatorAddr, validatorAddr)
+}
+
+func (sc stakingClient) QueryUnbondingDelegationContext(ctx context.Context, delegatorAddr string, validatorAddr string) (QueryUnbondingDelegationResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryUnbondingDelegationResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).UnbondingDelegation(
-		context.Background(),
+		ctx,
 		&QueryUnbondingDelegationRequest{
 			DelegatorAddr: delegatorAddr,
 			ValidatorAddr: validatorAddr,
@@ -290,15 +392,18 @@ func (sc stakingClient) QueryUnbondingDelegation(delegatorAddr string, validator
 }
 
 func (sc stakingClient) QueryDelegatorDelegations(delegatorAddr string, page, size uint64) (QueryDelegatorDelegationsResp, sdk.Error) {
+	return sc.QueryDelegatorDelegationsContext(context.Background(), delegatorAddr, page, size)
+}
+
+func (sc stakingClient) QueryDelegatorDelegationsContext(ctx context.Context, delegatorAddr string, page, size uint64) (QueryDelegatorDelegationsResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryDelegatorDelegationsResp{}, sdk.Wrap(err)
 	}
 
 	offset, limit := utils.ParsePage(page, size)
 	res, err := NewQueryClient(conn).DelegatorDelegations(
-		context.Background(),
+		ctx,
 		&QueryDelegatorDelegationsRequest{
 			DelegatorAddr: delegatorAddr,
 			Pagination: &query.PageRequest{
@@ -315,15 +420,18 @@ func (sc stakingClient) QueryDelegatorDelegations(delegatorAddr string, page, si
 }
 
 func (sc stakingClient) QueryDelegatorUnbondingDelegations(delegatorAddr string, page, size uint64) (QueryDelegatorUnbondingDelegationsResp, sdk.Error) {
+	return sc.QueryDelegatorUnbondingDelegationsContext(context.Background(), delegatorAddr, page, size)
+}
+
+func (sc stakingClient) QueryDelegatorUnbondingDelegationsContext(ctx context.Context, delegatorAddr string, page, size uint64) (QueryDelegatorUnbondingDelegationsResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryDelegatorUnbondingDelegationsResp{}, sdk.Wrap(err)
 	}
 
 	offset, limit := utils.ParsePage(page, size)
 	res, err := NewQueryClient(conn).DelegatorUnbondingDelegations(
-		context.Background(),
+		ctx,
 		&QueryDelegatorUnbondingDelegationsRequest{
 			DelegatorAddr: delegatorAddr,
 			Pagination: &query.PageRequest{
@@ -340,15 +448,18 @@ func (sc stakingClient) QueryDelegatorUnbondingDelegations(delegatorAddr string,
 }
 
 func (sc stakingClient) QueryRedelegations(request QueryRedelegationsReq) (QueryRedelegationsResp, sdk.Error) {
+	return sc.QueryRedelegationsContext(context.Background(), request)
+}
+
+func (sc stakingClient) QueryRedelegationsContext(ctx context.Context, request QueryRedelegationsReq) (QueryRedelegationsResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryRedelegationsResp{}, sdk.Wrap(err)
 	}
 
 	offset, limit := utils.ParsePage(request.Page, request.Size)
 	res, err := NewQueryClient(conn).Redelegations(
-		context.Background(),
+		ctx,
 		&QueryRedelegationsRequest{
 			DelegatorAddr:    request.DelegatorAddr,
 			SrcValidatorAddr: request.SrcValidatorAddr,
@@ -367,15 +478,18 @@ func (sc stakingClient) QueryRedelegations(request QueryRedelegationsReq) (Query
 }
 
 func (sc stakingClient) QueryDelegatorValidators(delegatorAddr string, page, size uint64) (QueryDelegatorValidatorsResp, sdk.Error) {
+	return sc.QueryDelegatorValidatorsContext(context.Background(), delegatorAddr, page, size)
+}
+
+func (sc stakingClient) QueryDelegatorValidatorsContext(ctx context.Context, delegatorAddr string, page, size uint64) (QueryDelegatorValidatorsResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryDelegatorValidatorsResp{}, sdk.Wrap(err)
 	}
 
 	offset, limit := utils.ParsePage(page, size)
 	res, err := NewQueryClient(conn).DelegatorValidators(
-		context.Background(),
+		ctx,
 		&QueryDelegatorValidatorsRequest{
 			DelegatorAddr: delegatorAddr,
 			Pagination: &query.PageRequest{
@@ -392,14 +506,17 @@ func (sc stakingClient) QueryDelegatorValidators(delegatorAddr string, page, siz
 }
 
 func (sc stakingClient) QueryDelegatorValidator(delegatorAddr string, validatorAddr string) (QueryValidatorResp, sdk.Error) {
+	return sc.QueryDelegatorValidatorContext(context.Background(), delegatorAddr, validatorAddr)
+}
+
+func (sc stakingClient) QueryDelegatorValidatorContext(ctx context.Context, delegatorAddr string, validatorAddr string) (QueryValidatorResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryValidatorResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).DelegatorValidator(
-		context.Background(),
+		ctx,
 		&QueryDelegatorValidatorRequest{
 			DelegatorAddr: delegatorAddr,
 			ValidatorAddr: validatorAddr,
@@ -413,14 +530,17 @@ func (sc stakingClient) QueryDelegatorValidator(delegatorAddr string, validatorA
 
 // QueryHistoricalInfo tendermint only save latest 100 block, previous block is aborted
 func (sc stakingClient) QueryHistoricalInfo(height int64) (QueryHistoricalInfoResp, sdk.Error) {
+	return sc.QueryHistoricalInfoContext(context.Background(), height)
+}
+
+func (sc stakingClient) QueryHistoricalInfoContext(ctx context.Context, height int64) (QueryHistoricalInfoResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryHistoricalInfoResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).HistoricalInfo(
-		context.Background(),
+		ctx,
 		&QueryHistoricalInfoRequest{
 			Height: height,
 		},
@@ -432,14 +552,17 @@ func (sc stakingClient) QueryHistoricalInfo(height int64) (QueryHistoricalInfoRe
 }
 
 func (sc stakingClient) QueryPool() (QueryPoolResp, sdk.Error) {
+	return sc.QueryPoolContext(context.Background())
+}
+
+func (sc stakingClient) QueryPoolContext(ctx context.Context) (QueryPoolResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryPoolResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Pool(
-		context.Background(),
+		ctx,
 		&QueryPoolRequest{},
 	)
 	if err != nil {
@@ -453,14 +576,17 @@ func (sc stakingClient) QueryPool() (QueryPoolResp, sdk.Error) {
 }
 
 func (sc stakingClient) QueryParams() (QueryParamsResp, sdk.Error) {
+	return sc.QueryParamsContext(context.Background())
+}
+
+func (sc stakingClient) QueryParamsContext(ctx context.Context) (QueryParamsResp, sdk.Error) {
 	conn, err := sc.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryParamsResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Params(
-		context.Background(),
+		ctx,
 		&QueryParamsRequest{},
 	)
 	if err != nil {
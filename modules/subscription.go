@@ -0,0 +1,134 @@
+package modules
+
+import (
+	"fmt"
+	"sync"
+
+	sdk "plugchain-sdk-go/types"
+)
+
+const (
+	defaultEventBuffer = 100
+	// eventErrorBuffer is the number of errors kept for a consumer not reading Subscription.Errors
+	eventErrorBuffer = 10
+)
+
+// eventQueue buffers the events of a subscription in their order, according to its overflow policy
+type eventQueue struct {
+	events   chan sdk.EventData
+	policy   sdk.OverflowPolicy
+	done     chan struct{}
+	doneOnce sync.Once
+
+	// mtx serializes push and close, so that events is never written once closed
+	mtx    sync.Mutex
+	closed bool
+
+	errMtx    sync.Mutex
+	errs      chan error
+	errClosed bool
+}
+
+func newEventQueue(opts sdk.SubscribeOptions) *eventQueue {
+	size := opts.BufferSize
+	if size <= 0 {
+		size = defaultEventBuffer
+	}
+	return &eventQueue{
+		events: make(chan sdk.EventData, size),
+		policy: opts.Overflow,
+		done:   make(chan struct{}),
+		errs:   make(chan error, eventErrorBuffer),
+	}
+}
+
+// push appends an event, false is returned if the buffer is full with the OverflowError policy
+func (q *eventQueue) push(data sdk.EventData) bool {
+	q.mtx.Lock()
+	defer q.mtx.Unlock()
+
+	if q.closed {
+		return true
+	}
+
+	switch q.policy {
+	case sdk.OverflowDropOldest:
+		for {
+			select {
+			case q.events <- data:
+				return true
+			default:
+			}
+			select {
+			case <-q.events:
+			default:
+			}
+		}
+	case sdk.OverflowError:
+		select {
+		case q.events <- data:
+			return true
+		default:
+			return false
+		}
+	default:
+		select {
+		case q.events <- data:
+		case <-q.done:
+		}
+		return true
+	}
+}
+
+// report sends the error to the consumer, it is dropped if the consumer does not read them
+func (q *eventQueue) report(err error) {
+	q.errMtx.Lock()
+	defer q.errMtx.Unlock()
+
+	if q.errClosed {
+		return
+	}
+	select {
+	case q.errs <- err:
+	default:
+	}
+}
+
+// close ends the subscription, the buffered events are still delivered
+func (q *eventQueue) close(err error) {
+	// unblock a push waiting for the consumer
+	q.doneOnce.Do(func() { close(q.done) })
+
+	q.mtx.Lock()
+	if !q.closed {
+		q.closed = true
+		close(q.events)
+	}
+	q.mtx.Unlock()
+
+	if err != nil {
+		q.report(err)
+	}
+	q.errMtx.Lock()
+	if !q.errClosed {
+		q.errClosed = true
+		close(q.errs)
+	}
+	q.errMtx.Unlock()
+}
+
+// handle calls the handler with every event in order, the panics of the handler are reported
+func (q *eventQueue) handle(handler sdk.EventHandler) {
+	for data := range q.events {
+		q.call(handler, data)
+	}
+}
+
+func (q *eventQueue) call(handler sdk.EventHandler, data sdk.EventData) {
+	defer func() {
+		if r := recover(); r != nil {
+			q.report(fmt.Errorf("event handler panic: %v", r))
+		}
+	}()
+	handler(data)
+}
@@ -0,0 +1,79 @@
+package modules
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "plugchain-sdk-go/types"
+)
+
+func TestEventQueue(t *testing.T) {
+	q := newEventQueue(sdk.SubscribeOptions{BufferSize: 2, Overflow: sdk.OverflowDropOldest})
+	for i := 1; i <= 3; i++ {
+		require.True(t, q.push(i))
+	}
+	require.Equal(t, 2, <-q.events)
+	require.Equal(t, 3, <-q.events)
+
+	q = newEventQueue(sdk.SubscribeOptions{BufferSize: 1, Overflow: sdk.OverflowError})
+	require.True(t, q.push(1))
+	require.False(t, q.push(2))
+
+	// the handler is called in order and its panics are reported
+	q = newEventQueue(sdk.SubscribeOptions{BufferSize: 3})
+	for i := 1; i <= 3; i++ {
+		require.True(t, q.push(i))
+	}
+	var handled []int
+	go q.handle(func(data sdk.EventData) {
+		handled = append(handled, data.(int))
+		if data.(int) == 2 {
+			panic("handler failed")
+		}
+		if data.(int) == 3 {
+			q.close(nil)
+		}
+	})
+	require.EqualError(t, <-q.errs, "event handler panic: handler failed")
+	_, ok := <-q.errs
+	require.False(t, ok)
+	require.Equal(t, []int{1, 2, 3}, handled)
+
+	// a push waiting for the consumer is released by close
+	q = newEventQueue(sdk.SubscribeOptions{BufferSize: 1})
+	require.True(t, q.push(1))
+	done := make(chan bool)
+	go func() { done <- q.push(2) }()
+	q.close(sdk.ErrSubscriptionOverflow)
+	require.True(t, <-done)
+	require.Equal(t, sdk.ErrSubscriptionOverflow, <-q.errs)
+}
+
+func TestSubscribeChan(t *testing.T) {
+	server, _, queries := newStubWSNode(t)
+
+	client, err := newRPCClient(server.URL, nil, nil, log.NewNopLogger(), 1)
+	require.NoError(t, err)
+	defer client.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	query := tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
+	sub, e := client.SubscribeChan(ctx, query, sdk.SubscribeOptions{})
+	require.Nil(t, e)
+	require.Equal(t, query, receive(t, queries))
+
+	data := receive(t, sub.Events).(sdk.EventDataNewBlockHeader)
+	require.Equal(t, int64(1), data.Header.Height)
+
+	// the subscription ends with its context
+	cancel()
+	_, ok := <-sub.Events
+	require.False(t, ok)
+	_, ok = <-sub.Errors
+	require.False(t, ok)
+	require.False(t, client.ws.hasSubscription(sub.ID))
+}
@@ -22,19 +22,22 @@ type tokenQuery struct {
 }
 
 func (l tokenQuery) QueryToken(denom string) (sdk.Token, error) {
+	return l.QueryTokenContext(context.Background(), denom)
+}
+
+func (l tokenQuery) QueryTokenContext(ctx context.Context, denom string) (sdk.Token, error) {
 	denom = strings.ToLower(denom)
 	if t, err := l.Get(l.prefixKey(denom)); err == nil {
 		return t.(sdk.Token), nil
 	}
 
 	conn, err := l.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return sdk.Token{}, sdk.Wrap(err)
 	}
 
 	response, err := token.NewQueryClient(conn).Token(
-		context.Background(),
+		ctx,
 		&token.QueryTokenRequest{Denom: denom},
 	)
 	if err != nil {
@@ -64,8 +67,12 @@ func (l tokenQuery) SaveTokens(tokens ...sdk.Token) {
 }
 
 func (l tokenQuery) ToMinCoin(coins ...sdk.DecCoin) (dstCoins sdk.Coins, err sdk.Error) {
+	return l.ToMinCoinContext(context.Background(), coins...)
+}
+
+func (l tokenQuery) ToMinCoinContext(ctx context.Context, coins ...sdk.DecCoin) (dstCoins sdk.Coins, err sdk.Error) {
 	for _, coin := range coins {
-		token, err := l.QueryToken(coin.Denom)
+		token, err := l.QueryTokenContext(ctx, coin.Denom)
 		if err != nil {
 			return nil, sdk.Wrap(err)
 		}
@@ -80,8 +87,12 @@ func (l tokenQuery) ToMinCoin(coins ...sdk.DecCoin) (dstCoins sdk.Coins, err sdk
 }
 
 func (l tokenQuery) ToMainCoin(coins ...sdk.Coin) (dstCoins sdk.DecCoins, err sdk.Error) {
+	return l.ToMainCoinContext(context.Background(), coins...)
+}
+
+func (l tokenQuery) ToMainCoinContext(ctx context.Context, coins ...sdk.Coin) (dstCoins sdk.DecCoins, err sdk.Error) {
 	for _, coin := range coins {
-		token, err := l.QueryToken(coin.Denom)
+		token, err := l.QueryTokenContext(ctx, coin.Denom)
 		if err != nil {
 			return dstCoins, sdk.Wrap(err)
 		}
@@ -95,6 +106,24 @@ func (l tokenQuery) ToMainCoin(coins ...sdk.Coin) (dstCoins sdk.DecCoins, err sd
 	return dstCoins.Sort(), nil
 }
 
+// toMinGasPrices converts the gas prices to the min unit, keeping the decimals
+func (l tokenQuery) toMinGasPrices(ctx context.Context, prices sdk.DecCoins) (sdk.DecCoins, sdk.Error) {
+	var dstPrices sdk.DecCoins
+	for _, price := range prices {
+		token, err := l.QueryTokenContext(ctx, price.Denom)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+
+		minPrice, err := token.GetCoinType().ConvertToMinDecCoin(price)
+		if err != nil {
+			return nil, sdk.Wrap(err)
+		}
+		dstPrices = append(dstPrices, minPrice)
+	}
+	return dstPrices.Sort(), nil
+}
+
 func (l tokenQuery) prefixKey(symbol string) string {
 	return fmt.Sprintf("token:%s", symbol)
 }
@@ -13,10 +13,18 @@ var (
 )
 
 func init() {
+	RegisterLegacyAminoCodec(amino)
 	cryptocodec.RegisterCrypto(amino)
 	amino.Seal()
 }
 
+func RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	cdc.RegisterConcrete(&MsgIssueToken{}, "plugchain/token/MsgIssueToken", nil)
+	cdc.RegisterConcrete(&MsgEditToken{}, "plugchain/token/MsgEditToken", nil)
+	cdc.RegisterConcrete(&MsgMintToken{}, "plugchain/token/MsgMintToken", nil)
+	cdc.RegisterConcrete(&MsgTransferOwnerToken{}, "plugchain/token/MsgTransferOwnerToken", nil)
+}
+
 func RegisterInterfaces(registry types.InterfaceRegistry) {
 	registry.RegisterImplementations(
 		(*sdk.Msg)(nil),
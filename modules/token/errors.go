@@ -0,0 +1,16 @@
+package token
+
+import (
+	sdk "plugchain-sdk-go/types"
+)
+
+// the codes of the errors of the token module returned by the node
+const (
+	codeTokenNotExists uint32 = 10
+	codeInvalidOwner   uint32 = 12
+)
+
+func init() {
+	sdk.RegisterABCIError(ModuleName, codeTokenNotExists, sdk.ErrNotFound)
+	sdk.RegisterABCIError(ModuleName, codeInvalidOwner, sdk.ErrUnauthorized)
+}
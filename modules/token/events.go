@@ -0,0 +1,56 @@
+package token
+
+import (
+	sdk "plugchain-sdk-go/types"
+)
+
+// IssueTokenEvent is emitted when a token is issued
+type IssueTokenEvent struct {
+	Symbol string `event:"symbol"`
+	Owner  string `event:"owner"`
+}
+
+func (IssueTokenEvent) EventType() string { return "issue_token" }
+
+// EditTokenEvent is emitted when a token is edited by its owner
+type EditTokenEvent struct {
+	Symbol string `event:"symbol"`
+	Owner  string `event:"owner"`
+}
+
+func (EditTokenEvent) EventType() string { return "edit_token" }
+
+// MintTokenEvent is emitted when tokens are minted to the recipient
+type MintTokenEvent struct {
+	Symbol    string `event:"symbol"`
+	Amount    uint64 `event:"amount"`
+	Recipient string `event:"recipient"`
+}
+
+func (MintTokenEvent) EventType() string { return "mint_token" }
+
+// BurnTokenEvent is emitted when tokens are burnt by their owner
+type BurnTokenEvent struct {
+	Symbol string `event:"symbol"`
+	Amount uint64 `event:"amount"`
+	Owner  string `event:"owner"`
+}
+
+func (BurnTokenEvent) EventType() string { return "burn_token" }
+
+// TransferOwnerTokenEvent is emitted when the ownership of a token is transferred
+type TransferOwnerTokenEvent struct {
+	Symbol string `event:"symbol"`
+	Owner  string `event:"owner"`
+	To     string `event:"to"`
+}
+
+func (TransferOwnerTokenEvent) EventType() string { return "transfer_owner_token" }
+
+func init() {
+	sdk.RegisterEvent(IssueTokenEvent{})
+	sdk.RegisterEvent(EditTokenEvent{})
+	sdk.RegisterEvent(MintTokenEvent{})
+	sdk.RegisterEvent(BurnTokenEvent{})
+	sdk.RegisterEvent(TransferOwnerTokenEvent{})
+}
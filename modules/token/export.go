@@ -1,6 +1,8 @@
 package token
 
 import (
+	"context"
+
 	sdk "plugchain-sdk-go/types"
 )
 
@@ -12,10 +14,25 @@ type Client interface {
 	TransferToken(to string, symbol string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 	MintToken(symbol string, amount uint64, to string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
 
+	// the msgs of the above methods, to be sent together with the msgs of other modules
+	IssueTokenMsg(owner string, req IssueTokenRequest) (sdk.Msg, sdk.Error)
+	EditTokenMsg(owner string, req EditTokenRequest) (sdk.Msg, sdk.Error)
+	TransferTokenMsg(owner, to string, symbol string) (sdk.Msg, sdk.Error)
+	MintTokenMsg(owner, symbol string, amount uint64, to string) (sdk.Msg, sdk.Error)
+
 	QueryToken(symbol string) (sdk.Token, error)
 	QueryTokens(owner string) (sdk.Tokens, error)
 	QueryFees(symbol string) (QueryFeesResp, error)
 	QueryParams() (QueryParamsResp, error)
+
+	IssueTokenContext(ctx context.Context, req IssueTokenRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	EditTokenContext(ctx context.Context, req EditTokenRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	TransferTokenContext(ctx context.Context, to string, symbol string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	MintTokenContext(ctx context.Context, symbol string, amount uint64, to string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error)
+	QueryTokenContext(ctx context.Context, symbol string) (sdk.Token, error)
+	QueryTokensContext(ctx context.Context, owner string) (sdk.Tokens, error)
+	QueryFeesContext(ctx context.Context, symbol string) (QueryFeesResp, error)
+	QueryParamsContext(ctx context.Context) (QueryParamsResp, error)
 }
 
 type IssueTokenRequest struct {
@@ -30,13 +30,34 @@ func (t tokenClient) RegisterInterfaceTypes(registry types.InterfaceRegistry) {
 	RegisterInterfaces(registry)
 }
 
+func (t tokenClient) RegisterLegacyAminoCodec(cdc *codec.LegacyAmino) {
+	RegisterLegacyAminoCodec(cdc)
+}
+
 func (t tokenClient) IssueToken(req IssueTokenRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return t.IssueTokenContext(context.Background(), req, baseTx)
+}
+
+func (t tokenClient) IssueTokenContext(ctx context.Context, req IssueTokenRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	owner, err := t.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	msg := &MsgIssueToken{
+	msg, err := t.IssueTokenMsg(owner.String(), req)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return t.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// IssueTokenMsg returns the msg of IssueToken for the account `owner`
+func (t tokenClient) IssueTokenMsg(owner string, req IssueTokenRequest) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(owner); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	return &MsgIssueToken{
 		Symbol:        req.Symbol,
 		Name:          req.Name,
 		Scale:         req.Scale,
@@ -44,75 +65,128 @@ func (t tokenClient) IssueToken(req IssueTokenRequest, baseTx sdk.BaseTx) (sdk.R
 		InitialSupply: req.InitialSupply,
 		MaxSupply:     req.MaxSupply,
 		Mintable:      req.Mintable,
-		Owner:         owner.String(),
-	}
-
-	return t.BuildAndSend([]sdk.Msg{msg}, baseTx)
+		Owner:         owner,
+	}, nil
 }
 
 func (t tokenClient) EditToken(req EditTokenRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return t.EditTokenContext(context.Background(), req, baseTx)
+}
+
+func (t tokenClient) EditTokenContext(ctx context.Context, req EditTokenRequest, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	owner, err := t.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	msg := &MsgEditToken{
+	msg, err := t.EditTokenMsg(owner.String(), req)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return t.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// EditTokenMsg returns the msg of EditToken for the account `owner`
+func (t tokenClient) EditTokenMsg(owner string, req EditTokenRequest) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(owner); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	return &MsgEditToken{
 		Symbol:    req.Symbol,
 		Name:      req.Name,
 		MaxSupply: req.MaxSupply,
-		Owner:     owner.String(),
-	}
-
-	return t.BuildAndSend([]sdk.Msg{msg}, baseTx)
+		Owner:     owner,
+	}, nil
 }
 
 func (t tokenClient) TransferToken(to string, symbol string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return t.TransferTokenContext(context.Background(), to, symbol, baseTx)
+}
+
+func (t tokenClient) TransferTokenContext(ctx context.Context, to string, symbol string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	owner, err := t.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
+	msg, err := t.TransferTokenMsg(owner.String(), to, symbol)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return t.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// TransferTokenMsg returns the msg of TransferToken for the account `owner`
+func (t tokenClient) TransferTokenMsg(owner, to string, symbol string) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(owner); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
 	if err := sdk.ValidateAccAddress(to); err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return nil, sdk.Wrap(err)
 	}
 
-	msg := &MsgTransferOwnerToken{
-		Owner:  owner.String(),
+	return &MsgTransferOwnerToken{
+		Owner:  owner,
 		To:     to,
 		Symbol: symbol,
-	}
-	return t.BuildAndSend([]sdk.Msg{msg}, baseTx)
+	}, nil
 }
 
 func (t tokenClient) MintToken(symbol string, amount uint64, to string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
+	return t.MintTokenContext(context.Background(), symbol, amount, to, baseTx)
+}
+
+func (t tokenClient) MintTokenContext(ctx context.Context, symbol string, amount uint64, to string, baseTx sdk.BaseTx) (sdk.ResultTx, sdk.Error) {
 	owner, err := t.QueryAddress(baseTx.From, baseTx.Password)
 	if err != nil {
 		return sdk.ResultTx{}, sdk.Wrap(err)
 	}
 
-	receipt := owner.String()
+	msg, err := t.MintTokenMsg(owner.String(), symbol, amount, to)
+	if err != nil {
+		return sdk.ResultTx{}, err
+	}
+	return t.BuildAndSendContext(ctx, []sdk.Msg{msg}, baseTx)
+}
+
+// MintTokenMsg returns the msg of MintToken for the account `owner`, the tokens are minted to the owner if `to` is empty
+func (t tokenClient) MintTokenMsg(owner, symbol string, amount uint64, to string) (sdk.Msg, sdk.Error) {
+	if err := sdk.ValidateAccAddress(owner); err != nil {
+		return nil, sdk.Wrap(err)
+	}
+
+	receipt := owner
 	if len(to) != 0 {
 		if err := sdk.ValidateAccAddress(to); err != nil {
-			return sdk.ResultTx{}, sdk.Wrap(err)
+			return nil, sdk.Wrap(err)
 		} else {
 			receipt = to
 		}
 	}
 
-	msg := &MsgMintToken{
+	return &MsgMintToken{
 		Symbol: symbol,
 		Amount: amount,
 		To:     receipt,
-		Owner:  owner.String(),
-	}
-	return t.BuildAndSend([]sdk.Msg{msg}, baseTx)
+		Owner:  owner,
+	}, nil
 }
 
 func (t tokenClient) QueryToken(denom string) (sdk.Token, error) {
-	return t.BaseClient.QueryToken(denom)
+	return t.QueryTokenContext(context.Background(), denom)
+}
+
+func (t tokenClient) QueryTokenContext(ctx context.Context, denom string) (sdk.Token, error) {
+	return t.BaseClient.QueryTokenContext(ctx, denom)
 }
 
 func (t tokenClient) QueryTokens(owner string) (sdk.Tokens, error) {
+	return t.QueryTokensContext(context.Background(), owner)
+}
+
+func (t tokenClient) QueryTokensContext(ctx context.Context, owner string) (sdk.Tokens, error) {
 	var ownerAddr string
 	if len(owner) > 0 {
 		if err := sdk.ValidateAccAddress(owner); err != nil {
@@ -122,7 +196,6 @@ func (t tokenClient) QueryTokens(owner string) (sdk.Tokens, error) {
 	}
 
 	conn, err := t.GenConn()
-	defer func() { _ = conn.Close() }()
 
 	if err != nil {
 		return sdk.Tokens{}, sdk.Wrap(err)
@@ -132,7 +205,7 @@ func (t tokenClient) QueryTokens(owner string) (sdk.Tokens, error) {
 		Owner: ownerAddr,
 	}
 
-	res, err := NewQueryClient(conn).Tokens(context.Background(), request)
+	res, err := NewQueryClient(conn).Tokens(ctx, request)
 	if err != nil {
 		return sdk.Tokens{}, err
 	}
@@ -152,8 +225,11 @@ func (t tokenClient) QueryTokens(owner string) (sdk.Tokens, error) {
 }
 
 func (t tokenClient) QueryFees(symbol string) (QueryFeesResp, error) {
+	return t.QueryFeesContext(context.Background(), symbol)
+}
+
+func (t tokenClient) QueryFeesContext(ctx context.Context, symbol string) (QueryFeesResp, error) {
 	conn, err := t.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryFeesResp{}, sdk.Wrap(err)
 	}
@@ -162,7 +238,7 @@ func (t tokenClient) QueryFees(symbol string) (QueryFeesResp, error) {
 		Symbol: symbol,
 	}
 
-	res, err := NewQueryClient(conn).Fees(context.Background(), request)
+	res, err := NewQueryClient(conn).Fees(ctx, request)
 	if err != nil {
 		return QueryFeesResp{}, err
 	}
@@ -171,14 +247,17 @@ func (t tokenClient) QueryFees(symbol string) (QueryFeesResp, error) {
 }
 
 func (t tokenClient) QueryParams() (QueryParamsResp, error) {
+	return t.QueryParamsContext(context.Background())
+}
+
+func (t tokenClient) QueryParamsContext(ctx context.Context) (QueryParamsResp, error) {
 	conn, err := t.GenConn()
-	defer func() { _ = conn.Close() }()
 	if err != nil {
 		return QueryParamsResp{}, sdk.Wrap(err)
 	}
 
 	res, err := NewQueryClient(conn).Params(
-		context.Background(),
+		ctx,
 		&QueryParamsRequest{},
 	)
 	if err != nil {
@@ -4,10 +4,14 @@ import (
 	"context"
 	"encoding/hex"
 	"errors"
+	"reflect"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gogo/protobuf/proto"
+	"github.com/tendermint/tendermint/mempool"
 
 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
 
@@ -17,42 +21,86 @@ import (
 
 // QueryTx returns the tx info
 func (base baseClient) QueryTx(hash string) (sdk.ResultQueryTx, error) {
+	return base.QueryTxContext(context.Background(), hash)
+}
+
+func (base baseClient) QueryTxContext(ctx context.Context, hash string) (sdk.ResultQueryTx, error) {
 	tx, err := hex.DecodeString(hash)
 	if err != nil {
 		return sdk.ResultQueryTx{}, err
 	}
 
-	res, err := base.Tx(context.Background(), tx, true)
+	res, err := base.Tx(ctx, tx, true)
 	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return sdk.ResultQueryTx{}, sdk.GetError(sdk.RootCodespace, uint32(sdk.NotFound), err.Error())
+		}
 		return sdk.ResultQueryTx{}, err
 	}
 
-	resBlocks, err := base.getResultBlocks([]*ctypes.ResultTx{res})
+	blockTimes, err := base.blockTimes(ctx, []*ctypes.ResultTx{res})
 	if err != nil {
 		return sdk.ResultQueryTx{}, err
 	}
-	return base.parseTxResult(res, resBlocks[res.Height])
+	return base.parseTxResult(res, blockTimes[res.Height])
 }
 
 func (base baseClient) QueryTxs(builder *sdk.EventQueryBuilder, page, size *int) (sdk.ResultSearchTxs, error) {
+	return base.QueryTxsContext(context.Background(), builder, page, size)
+}
+
+func (base baseClient) QueryTxsContext(ctx context.Context, builder *sdk.EventQueryBuilder, page, size *int) (sdk.ResultSearchTxs, error) {
+	var req sdk.TxSearchRequest
+	if page != nil {
+		req.Page = *page
+	}
+	if size != nil {
+		req.Size = *size
+	}
+	return base.SearchTxsContext(ctx, builder, req)
+}
+
+func (base baseClient) SearchTxs(builder *sdk.EventQueryBuilder, req sdk.TxSearchRequest) (sdk.ResultSearchTxs, error) {
+	return base.SearchTxsContext(context.Background(), builder, req)
+}
+
+func (base baseClient) SearchTxsContext(ctx context.Context, builder *sdk.EventQueryBuilder, req sdk.TxSearchRequest) (sdk.ResultSearchTxs, error) {
 	query := builder.Build()
 	if len(query) == 0 {
 		return sdk.ResultSearchTxs{}, errors.New("must declare at least one tag to search")
 	}
+	if err := builder.Err(); err != nil {
+		return sdk.ResultSearchTxs{}, err
+	}
+
+	order := req.Order
+	if len(order) == 0 {
+		order = sdk.TxOrderAsc
+	}
+	if order != sdk.TxOrderAsc && order != sdk.TxOrderDesc {
+		return sdk.ResultSearchTxs{}, sdk.Wrapf("invalid order %s", order)
+	}
+	var page, size *int
+	if req.Page > 0 {
+		page = &req.Page
+	}
+	if req.Size > 0 {
+		size = &req.Size
+	}
 
-	res, err := base.TxSearch(context.Background(), query, true, page, size, "asc")
+	res, err := base.TxSearch(ctx, query, true, page, size, string(order))
 	if err != nil {
 		return sdk.ResultSearchTxs{}, err
 	}
 
-	resBlocks, err := base.getResultBlocks(res.Txs)
+	blockTimes, err := base.blockTimes(ctx, res.Txs)
 	if err != nil {
 		return sdk.ResultSearchTxs{}, err
 	}
 
 	var txs []sdk.ResultQueryTx
-	for i, tx := range res.Txs {
-		txInfo, err := base.parseTxResult(tx, resBlocks[res.Txs[i].Height])
+	for _, tx := range res.Txs {
+		txInfo, err := base.parseTxResult(tx, blockTimes[tx.Height])
 		if err != nil {
 			return sdk.ResultSearchTxs{}, err
 		}
@@ -65,13 +113,36 @@ func (base baseClient) QueryTxs(builder *sdk.EventQueryBuilder, page, size *int)
 	}, nil
 }
 
+func (base baseClient) IterateTxs(ctx context.Context, builder *sdk.EventQueryBuilder, order sdk.TxOrder, size int) *sdk.TxIterator {
+	return sdk.NewTxIterator(func(page int) (sdk.ResultSearchTxs, error) {
+		if page == 1 && len(builder.Build()) > 0 {
+			// the txs of the new blocks would shift the pages, the search stops at the latest height
+			latest, err := base.latestHeight(ctx)
+			if err != nil {
+				return sdk.ResultSearchTxs{}, err
+			}
+			// the conditions are copied, so that the builder of the caller is unchanged
+			pinned, err := sdk.ParseEventQuery(builder.Build())
+			if err != nil {
+				return sdk.ResultSearchTxs{}, err
+			}
+			builder = pinned.AddRange(sdk.TxHeightKey, nil, latest)
+		}
+		return base.SearchTxsContext(ctx, builder, sdk.TxSearchRequest{Page: page, Size: size, Order: order})
+	})
+}
+
 func (base baseClient) QueryBlock(height int64) (sdk.BlockDetail, error) {
-	block, err := base.Block(context.Background(), &height)
+	return base.QueryBlockContext(context.Background(), height)
+}
+
+func (base baseClient) QueryBlockContext(ctx context.Context, height int64) (sdk.BlockDetail, error) {
+	block, err := base.Block(ctx, &height)
 	if err != nil {
 		return sdk.BlockDetail{}, err
 	}
 
-	blockResult, err := base.BlockResults(context.Background(), &height)
+	blockResult, err := base.BlockResults(ctx, &height)
 	if err != nil {
 		return sdk.BlockDetail{}, err
 	}
@@ -83,13 +154,89 @@ func (base baseClient) QueryBlock(height int64) (sdk.BlockDetail, error) {
 	}, nil
 }
 
-func (base baseClient) EstimateTxGas(txBytes []byte) (uint64, error) {
-	res, err := base.ABCIQuery(context.Background(), "/app/simulate", txBytes)
+// WaitForTx waits until the tx is included in a block and returns its result
+func (base baseClient) WaitForTx(hash string, timeout time.Duration) (sdk.ResultTx, sdk.Error) {
+	return base.WaitForTxContext(context.Background(), hash, timeout)
+}
+
+// WaitForTxContext subscribes to the tx and polls QueryTx in case the event is missed,
+// a TxTimeoutError is returned if the tx is not included within timeout and the error of the
+// query if it fails for another reason. The result is returned together with an error if the
+// tx failed in DeliverTx.
+func (base baseClient) WaitForTxContext(ctx context.Context, hash string, timeout time.Duration) (sdk.ResultTx, sdk.Error) {
+	hash = strings.ToUpper(hash)
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	included := make(chan sdk.ResultTx, 1)
+	builder := sdk.NewEventQueryBuilder().AddCondition(sdk.Cond(sdk.TxHashKey).EQ(hash))
+	subscription, err := base.SubscribeTx(builder, func(tx sdk.EventDataTx) {
+		select {
+		case included <- resultTxFromTxResult(tx.Hash, tx.Height, tx.Result):
+		default:
+		}
+	})
 	if err != nil {
-		return 0, err
+		base.Logger().Debug("subscribe tx failed, polling the tx", "hash", hash, "errMsg", err.Error())
+	} else {
+		defer func() { _ = base.Unsubscribe(subscription) }()
 	}
 
-	simRes, err := parseQueryResponse(res.Response.Value)
+	ticker := time.NewTicker(waitTxPollInterval)
+	defer ticker.Stop()
+	for {
+		// the tx may be included before the subscription is created
+		tx, err := base.QueryTxContext(ctx, hash)
+		switch {
+		case err == nil:
+			res := resultTxFromTxResult(tx.Hash, tx.Height, tx.Result)
+			base.confirmOutbox(res)
+			return checkTxResult(res)
+		case !errors.Is(err, sdk.ErrNotFound) && ctx.Err() == nil:
+			// the query failed for another reason than the tx not being included yet, e.g. the nodes are down
+			return sdk.ResultTx{Hash: hash}, sdk.Wrap(err)
+		}
+
+		select {
+		case res := <-included:
+			base.confirmOutbox(res)
+			return checkTxResult(res)
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return sdk.ResultTx{Hash: hash}, sdk.TxTimeoutError{Hash: hash, Timeout: timeout}
+			}
+			return sdk.ResultTx{Hash: hash}, sdk.Wrap(ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+func resultTxFromTxResult(hash string, height int64, result sdk.TxResult) sdk.ResultTx {
+	return sdk.ResultTx{
+		GasWanted:  result.GasWanted,
+		GasUsed:    result.GasUsed,
+		Events:     result.Events,
+		ABCIEvents: result.ABCIEvents,
+		Hash:       hash,
+		Height:     height,
+		Code:       result.Code,
+		Codespace:  result.Codespace,
+		Log:        result.Log,
+	}
+}
+
+func checkTxResult(res sdk.ResultTx) (sdk.ResultTx, sdk.Error) {
+	if res.Code != 0 {
+		return res, sdk.GetError(res.Codespace, res.Code, res.Log)
+	}
+	return res, nil
+}
+
+func (base baseClient) EstimateTxGas(ctx context.Context, txBytes []byte) (uint64, error) {
+	simRes, err := base.simulate(ctx, txBytes)
 	if err != nil {
 		return 0, err
 	}
@@ -98,12 +245,85 @@ func (base baseClient) EstimateTxGas(txBytes []byte) (uint64, error) {
 	return adjusted, nil
 }
 
-func (base *baseClient) buildTx(msgs []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *clienttx.Factory, sdk.Error) {
-	builder, err := base.prepare(baseTx)
+func (base *baseClient) Simulate(msgs []sdk.Msg, baseTx sdk.BaseTx) (sdk.ResultSimulateTx, sdk.Error) {
+	return base.SimulateContext(context.Background(), msgs, baseTx)
+}
+
+// SimulateContext runs msgs signed by baseTx.From on the node without sending them and returns
+// what the tx would do: the gas, the logs and events of every msg and the responses of the msgs
+func (base *baseClient) SimulateContext(ctx context.Context, msgs []sdk.Msg, baseTx sdk.BaseTx) (sdk.ResultSimulateTx, sdk.Error) {
+	builder, err := base.prepare(ctx, baseTx)
+	if err != nil {
+		return sdk.ResultSimulateTx{}, sdk.Wrap(err)
+	}
+
+	simTx, err := builder.BuildSimTx(baseTx.From, msgs)
+	if err != nil {
+		return sdk.ResultSimulateTx{}, sdk.Wrap(err)
+	}
+
+	simRes, err := base.simulate(ctx, simTx)
+	if err != nil {
+		return sdk.ResultSimulateTx{}, sdk.Wrap(err)
+	}
+
+	res, err := parseSimulationResponse(simRes, base.cfg.GasAdjustment)
+	if err != nil {
+		return sdk.ResultSimulateTx{}, sdk.Wrap(err)
+	}
+	return res, nil
+}
+
+// simulate runs the tx on the node without committing it
+func (base baseClient) simulate(ctx context.Context, txBytes []byte) (sdk.SimulationResponse, error) {
+	res, err := base.ABCIQuery(ctx, "/app/simulate", txBytes)
+	if err != nil {
+		return sdk.SimulationResponse{}, err
+	}
+
+	if !res.Response.IsOK() {
+		return sdk.SimulationResponse{}, sdk.GetError(res.Response.Codespace, res.Response.Code, res.Response.Log)
+	}
+
+	return parseQueryResponse(res.Response.Value)
+}
+
+// simulateGas replaces the gas limit of the builder with the adjusted simulation
+// estimate when the builder is in simulate-and-execute mode
+func (base *baseClient) simulateGas(ctx context.Context, builder *clienttx.Factory, name string, msgs []sdk.Msg) sdk.Error {
+	if !builder.SimulateAndExecute() {
+		return nil
+	}
+
+	simTx, err := builder.BuildSimTx(name, msgs)
+	if err != nil {
+		return sdk.Wrap(err)
+	}
+
+	gas, err := base.EstimateTxGas(ctx, simTx)
+	if err != nil {
+		return sdk.Wrap(err)
+	}
+
+	base.Logger().Debug("estimate transaction gas success", "gas", gas)
+	builder.WithGas(gas)
+	return nil
+}
+
+func (base *baseClient) buildTx(ctx context.Context, msgs []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *clienttx.Factory, sdk.Error) {
+	builder, err := base.prepare(ctx, baseTx)
 	if err != nil {
 		return nil, builder, sdk.Wrap(err)
 	}
 
+	if err := base.simulateGas(ctx, builder, baseTx.From, msgs); err != nil {
+		return nil, builder, err
+	}
+
+	if err := base.minGasPrices.check(builder); err != nil {
+		return nil, builder, err
+	}
+
 	txByte, err := builder.BuildAndSign(baseTx.From, msgs, false)
 	if err != nil {
 		return nil, builder, sdk.Wrap(err)
@@ -113,12 +333,21 @@ func (base *baseClient) buildTx(msgs []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *cli
 	return txByte, builder, nil
 }
 
-func (base *baseClient) buildTxWithAccount(addr string, accountNumber, sequence uint64, msgs []sdk.Msg, baseTx sdk.BaseTx) ([]byte, *clienttx.Factory, sdk.Error) {
-	builder, err := base.prepareTemp(addr, accountNumber, sequence, baseTx)
+func (base *baseClient) buildTxWithAccount(ctx context.Context, addr string, accountNumber, sequence uint64,
+	msgs []sdk.Msg, baseTx sdk.BaseTx, payer *accountSequence) ([]byte, *clienttx.Factory, sdk.Error) {
+	builder, err := base.prepareTemp(ctx, addr, accountNumber, sequence, baseTx, payer)
 	if err != nil {
 		return nil, builder, sdk.Wrap(err)
 	}
 
+	if err := base.simulateGas(ctx, builder, baseTx.From, msgs); err != nil {
+		return nil, builder, err
+	}
+
+	if err := base.minGasPrices.check(builder); err != nil {
+		return nil, builder, err
+	}
+
 	txByte, err := builder.BuildAndSign(baseTx.From, msgs, false)
 	if err != nil {
 		return nil, builder, sdk.Wrap(err)
@@ -128,9 +357,9 @@ func (base *baseClient) buildTxWithAccount(addr string, accountNumber, sequence
 	return txByte, builder, nil
 }
 
-func (base baseClient) broadcastTx(txBytes []byte, mode sdk.BroadcastMode, simulate bool) (res sdk.ResultTx, err sdk.Error) {
+func (base baseClient) broadcastTx(ctx context.Context, txBytes []byte, mode sdk.BroadcastMode, simulate bool) (res sdk.ResultTx, err sdk.Error) {
 	if simulate {
-		estimateGas, err := base.EstimateTxGas(txBytes)
+		estimateGas, err := base.EstimateTxGas(ctx, txBytes)
 		if err != nil {
 			return res, sdk.Wrap(err)
 		}
@@ -138,54 +367,61 @@ func (base baseClient) broadcastTx(txBytes []byte, mode sdk.BroadcastMode, simul
 		return res, nil
 	}
 
+	// the tx is recorded before it reaches the node, it may be included even if the broadcast fails
+	if err := base.recordOutbox(txBytes); err != nil {
+		return res, err
+	}
+
 	switch mode {
 	case sdk.Commit:
-		res, err = base.broadcastTxCommit(txBytes)
+		res, err = base.broadcastTxCommit(ctx, txBytes)
 	case sdk.Async:
-		res, err = base.broadcastTxAsync(txBytes)
+		res, err = base.broadcastTxAsync(ctx, txBytes)
 	case sdk.Sync:
-		res, err = base.broadcastTxSync(txBytes)
+		res, err = base.broadcastTxSync(ctx, txBytes)
 	default:
 		err = sdk.Wrapf("commit mode(%s) not supported", mode)
 	}
+	base.updateOutbox(txBytes, mode, res, err)
 	return
 }
 
 // broadcastTxCommit broadcasts transaction bytes to a Tendermint node
 // and waits for a commit.
-func (base baseClient) broadcastTxCommit(tx []byte) (sdk.ResultTx, sdk.Error) {
-	res, err := base.BroadcastTxCommit(context.Background(), tx)
+func (base baseClient) broadcastTxCommit(ctx context.Context, tx []byte) (sdk.ResultTx, sdk.Error) {
+	res, err := base.BroadcastTxCommit(ctx, tx)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return sdk.ResultTx{}, broadcastError(err)
 	}
 
 	if !res.CheckTx.IsOK() {
 		return sdk.ResultTx{}, sdk.GetError(res.CheckTx.Codespace, res.CheckTx.Code, res.CheckTx.Log)
 	}
 
-	if !res.DeliverTx.IsOK() {
-		return sdk.ResultTx{}, sdk.GetError(res.DeliverTx.Codespace, res.DeliverTx.Code, res.DeliverTx.Log)
-	}
-
-	return sdk.ResultTx{
-		GasWanted: res.DeliverTx.GasWanted,
-		GasUsed:   res.DeliverTx.GasUsed,
-		Events:    sdk.StringifyEvents(res.DeliverTx.Events),
-		Hash:      res.Hash.String(),
-		Height:    res.Height,
-	}, nil
+	// the result of a tx failed in DeliverTx is returned together with the error, it is included in the block
+	return checkTxResult(sdk.ResultTx{
+		GasWanted:  res.DeliverTx.GasWanted,
+		GasUsed:    res.DeliverTx.GasUsed,
+		Events:     sdk.StringifyEvents(res.DeliverTx.Events),
+		ABCIEvents: res.DeliverTx.Events,
+		Hash:       res.Hash.String(),
+		Height:     res.Height,
+		Code:       res.DeliverTx.Code,
+		Codespace:  res.DeliverTx.Codespace,
+		Log:        res.DeliverTx.Log,
+	})
 }
 
 // BroadcastTxSync broadcasts transaction bytes to a Tendermint node
 // synchronously.
-func (base baseClient) broadcastTxSync(tx []byte) (sdk.ResultTx, sdk.Error) {
-	res, err := base.BroadcastTxSync(context.Background(), tx)
+func (base baseClient) broadcastTxSync(ctx context.Context, tx []byte) (sdk.ResultTx, sdk.Error) {
+	res, err := base.BroadcastTxSync(ctx, tx)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return sdk.ResultTx{}, broadcastError(err)
 	}
 
 	if res.Code != 0 {
-		return sdk.ResultTx{}, sdk.GetError(sdk.RootCodespace, res.Code, res.Log)
+		return sdk.ResultTx{}, sdk.GetError(res.Codespace, res.Code, res.Log)
 	}
 
 	return sdk.ResultTx{Hash: res.Hash.String()}, nil
@@ -193,31 +429,93 @@ func (base baseClient) broadcastTxSync(tx []byte) (sdk.ResultTx, sdk.Error) {
 
 // BroadcastTxAsync broadcasts transaction bytes to a Tendermint node
 // asynchronously.
-func (base baseClient) broadcastTxAsync(tx []byte) (sdk.ResultTx, sdk.Error) {
-	res, err := base.BroadcastTxAsync(context.Background(), tx)
+func (base baseClient) broadcastTxAsync(ctx context.Context, tx []byte) (sdk.ResultTx, sdk.Error) {
+	res, err := base.BroadcastTxAsync(ctx, tx)
 	if err != nil {
-		return sdk.ResultTx{}, sdk.Wrap(err)
+		return sdk.ResultTx{}, broadcastError(err)
 	}
 
 	return sdk.ResultTx{Hash: res.Hash.String()}, nil
 }
 
-func (base baseClient) getResultBlocks(resTxs []*ctypes.ResultTx) (map[int64]*ctypes.ResultBlock, error) {
-	resBlocks := make(map[int64]*ctypes.ResultBlock)
+// broadcastError converts the errors of the mempool, which are returned by the rpc without
+// an ABCI code, e.g. "tx already exists in cache", to the errors of their code
+func broadcastError(err error) sdk.Error {
+	switch msg := err.Error(); {
+	case strings.Contains(msg, mempool.ErrTxInCache.Error()):
+		return sdk.GetError(sdk.RootCodespace, uint32(sdk.TxInMempoolCache), msg)
+	case strings.Contains(msg, "Tx too large"):
+		return sdk.GetError(sdk.RootCodespace, uint32(sdk.TxTooLarge), msg)
+	}
+	return sdk.Wrap(err)
+}
+
+// blockTimes returns the times of the blocks of the txs, the headers missing from the cache are fetched
+// by ranges of heights
+func (base baseClient) blockTimes(ctx context.Context, resTxs []*ctypes.ResultTx) (map[int64]time.Time, error) {
+	times := make(map[int64]time.Time)
+	var missing []int64
 	for _, resTx := range resTxs {
-		if _, ok := resBlocks[resTx.Height]; !ok {
-			resBlock, err := base.Block(context.Background(), &resTx.Height)
+		if _, ok := times[resTx.Height]; ok {
+			continue
+		}
+		if t, ok := base.cachedBlockTime(resTx.Height); ok {
+			times[resTx.Height] = t
+			continue
+		}
+		times[resTx.Height] = time.Time{}
+		missing = append(missing, resTx.Height)
+	}
+	sort.Slice(missing, func(i, j int) bool { return missing[i] < missing[j] })
+
+	for i := 0; i < len(missing); {
+		minHeight := missing[i]
+		maxHeight := minHeight + blockchainInfoMaxHeights - 1
+		res, err := base.BlockchainInfo(ctx, minHeight, maxHeight)
+		if err != nil {
+			return nil, err
+		}
+		for _, meta := range res.BlockMetas {
+			base.cacheBlockTime(meta.Header.Height, meta.Header.Time)
+			if _, ok := times[meta.Header.Height]; ok {
+				times[meta.Header.Height] = meta.Header.Time
+			}
+		}
+
+		for ; i < len(missing) && missing[i] <= maxHeight; i++ {
+			if !times[missing[i]].IsZero() {
+				continue
+			}
+			// not returned by the node, e.g. a height above its latest block
+			resBlock, err := base.Block(ctx, &missing[i])
 			if err != nil {
 				return nil, err
 			}
-
-			resBlocks[resTx.Height] = resBlock
+			base.cacheBlockTime(missing[i], resBlock.Block.Time)
+			times[missing[i]] = resBlock.Block.Time
 		}
 	}
-	return resBlocks, nil
+	return times, nil
 }
 
-func (base baseClient) parseTxResult(res *ctypes.ResultTx, resBlock *ctypes.ResultBlock) (sdk.ResultQueryTx, error) {
+func (base baseClient) cachedBlockTime(height int64) (time.Time, bool) {
+	if base.blockTimeCache == nil {
+		return time.Time{}, false
+	}
+	v, err := base.blockTimeCache.Get(height)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return v.(time.Time), true
+}
+
+func (base baseClient) cacheBlockTime(height int64, t time.Time) {
+	if base.blockTimeCache != nil {
+		_ = base.blockTimeCache.Set(height, t)
+	}
+}
+
+func (base baseClient) parseTxResult(res *ctypes.ResultTx, blockTime time.Time) (sdk.ResultQueryTx, error) {
 	var tx sdk.Tx
 	var err error
 
@@ -230,13 +528,15 @@ func (base baseClient) parseTxResult(res *ctypes.ResultTx, resBlock *ctypes.Resu
 		Height: res.Height,
 		Tx:     tx,
 		Result: sdk.TxResult{
-			Code:      res.TxResult.Code,
-			Log:       res.TxResult.Log,
-			GasWanted: res.TxResult.GasWanted,
-			GasUsed:   res.TxResult.GasUsed,
-			Events:    sdk.StringifyEvents(res.TxResult.Events),
+			Code:       res.TxResult.Code,
+			Codespace:  res.TxResult.Codespace,
+			Log:        res.TxResult.Log,
+			GasWanted:  res.TxResult.GasWanted,
+			GasUsed:    res.TxResult.GasUsed,
+			Events:     sdk.StringifyEvents(res.TxResult.Events),
+			ABCIEvents: res.TxResult.Events,
 		},
-		Timestamp: resBlock.Block.Time.Format(time.RFC3339),
+		Timestamp: blockTime.Format(time.RFC3339),
 	}, nil
 }
 
@@ -251,3 +551,50 @@ func parseQueryResponse(bz []byte) (sdk.SimulationResponse, error) {
 	}
 	return simRes, nil
 }
+
+func parseSimulationResponse(simRes sdk.SimulationResponse, gasAdjustment float64) (sdk.ResultSimulateTx, error) {
+	res := sdk.ResultSimulateTx{
+		GasInfo:     simRes.GasInfo,
+		GasEstimate: adjustGasEstimate(simRes.GasUsed, gasAdjustment),
+	}
+	if simRes.Result == nil {
+		return res, nil
+	}
+
+	res.Events = sdk.StringifyEvents(simRes.Result.Events)
+	if len(simRes.Result.Log) > 0 {
+		logs, err := sdk.ParseABCILogs(simRes.Result.Log)
+		if err != nil {
+			return res, err
+		}
+		res.Logs = logs
+	}
+
+	var txMsgData sdk.TxMsgData
+	if err := txMsgData.Unmarshal(simRes.Result.Data); err != nil {
+		return res, err
+	}
+	for _, data := range txMsgData.Data {
+		res.MsgResponses = append(res.MsgResponses, sdk.MsgResponse{
+			MsgType:  data.MsgType,
+			Data:     data.Data,
+			Response: decodeMsgResponse(data),
+		})
+	}
+	return res, nil
+}
+
+// decodeMsgResponse decodes the response of a msg by the type url of the msg,
+// e.g. "/cosmos.gov.v1beta1.MsgVote" is answered by "cosmos.gov.v1beta1.MsgVoteResponse"
+func decodeMsgResponse(data *sdk.MsgData) proto.Message {
+	typ := proto.MessageType(strings.TrimPrefix(data.MsgType, "/") + "Response")
+	if typ == nil || typ.Kind() != reflect.Ptr {
+		return nil
+	}
+
+	msg, ok := reflect.New(typ.Elem()).Interface().(proto.Message)
+	if !ok || proto.Unmarshal(data.Data, msg) != nil {
+		return nil
+	}
+	return msg
+}
@@ -0,0 +1,405 @@
+package modules
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gogo/protobuf/jsonpb"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	"github.com/tendermint/tendermint/mempool"
+	"github.com/tendermint/tendermint/p2p"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+
+	"plugchain-sdk-go/codec"
+	cdctypes "plugchain-sdk-go/codec/types"
+	"plugchain-sdk-go/modules/gov"
+	"plugchain-sdk-go/modules/token"
+	sdk "plugchain-sdk-go/types"
+	txtypes "plugchain-sdk-go/types/tx"
+	"plugchain-sdk-go/utils/cache"
+	"plugchain-sdk-go/utils/rpctest"
+)
+
+func TestErrors(t *testing.T) {
+	log := "account sequence mismatch, expected 12, got 10: incorrect account sequence"
+	_, err := checkTxResult(sdk.ResultTx{Code: 32, Codespace: sdk.RootCodespace, Log: log})
+	require.True(t, errors.Is(err, sdk.ErrWrongSequence))
+	require.False(t, errors.Is(err, sdk.ErrInsufficientFunds))
+
+	var abciErr sdk.ABCIError
+	require.True(t, errors.As(fmt.Errorf("send failed: %w", err), &abciErr))
+	require.Equal(t, log, abciErr.Log)
+	require.Equal(t, uint32(32), abciErr.Code())
+
+	// the codes of a module do not match the same codes of the root codespace
+	err = sdk.GetError(token.ModuleName, 10, "token plug does not exist")
+	require.True(t, errors.Is(err, sdk.ErrNotFound))
+	require.False(t, errors.Is(sdk.GetError(token.ModuleName, 5, "invalid initial supply"), sdk.ErrInsufficientFunds))
+
+	require.True(t, errors.Is(broadcastError(mempool.ErrTxInCache), sdk.ErrTxInMempoolCache))
+	require.True(t, errors.Is(sdk.TxTooLargeError{Size: 2, MaxBytes: 1}, sdk.ErrTxTooLarge))
+
+	// stdlib errors are kept by Wrap
+	require.True(t, errors.Is(sdk.Wrap(mempool.ErrTxInCache), mempool.ErrTxInCache))
+	require.Equal(t, sdk.ErrNotFound, sdk.Wrap(sdk.ErrNotFound))
+}
+
+func TestParseSimulationResponse(t *testing.T) {
+	proposal, err := (&gov.MsgSubmitProposalResponse{ProposalId: 7}).Marshal()
+	require.NoError(t, err)
+	data, err := (&sdk.TxMsgData{Data: []*sdk.MsgData{
+		{MsgType: "/cosmos.gov.v1beta1.MsgSubmitProposal", Data: proposal},
+		{MsgType: "/cosmos.bank.v1beta1.MsgSend"},
+	}}).Marshal()
+	require.NoError(t, err)
+
+	event := abci.Event{Type: "message", Attributes: []abci.EventAttribute{{Key: []byte("action"), Value: []byte("submit_proposal")}}}
+	simRes := &sdk.SimulationResponse{
+		GasInfo: sdk.GasInfo{GasWanted: 200000, GasUsed: 100000},
+		Result: &sdk.Result{
+			Data:   data,
+			Log:    `[{"msg_index":0,"events":[{"type":"message","attributes":[{"key":"action","value":"submit_proposal"}]}]},{"msg_index":1,"events":[]}]`,
+			Events: []abci.Event{event},
+		},
+	}
+	bz, err := (&jsonpb.Marshaler{}).MarshalToString(simRes)
+	require.NoError(t, err)
+
+	parsed, err := parseQueryResponse([]byte(bz))
+	require.NoError(t, err)
+	res, err := parseSimulationResponse(parsed, 1.5)
+	require.NoError(t, err)
+
+	require.Equal(t, uint64(150000), res.GasEstimate)
+	require.Equal(t, uint64(100000), res.GasInfo.GasUsed)
+	require.Len(t, res.Logs, 2)
+	require.Equal(t, "submit_proposal", res.Logs[0].Events[0].Attributes[0].Value)
+	require.Equal(t, sdk.StringifyEvents([]abci.Event{event}), res.Events)
+
+	require.Len(t, res.MsgResponses, 2)
+	require.Equal(t, &gov.MsgSubmitProposalResponse{ProposalId: 7}, res.MsgResponses[0].Response)
+	// MsgSendResponse is not registered in this sdk
+	require.Nil(t, res.MsgResponses[1].Response)
+}
+
+func TestIterateTxs(t *testing.T) {
+	txConfig := txtypes.NewTxConfig(codec.NewProtoCodec(cdctypes.NewInterfaceRegistry()), txtypes.DefaultSignModes)
+	txBytes, err := txConfig.TxEncoder()(txConfig.NewTxBuilder().GetTx())
+	require.NoError(t, err)
+	blockTime := func(height int64) time.Time {
+		return time.Date(2021, 1, 1, 0, 0, int(height), 0, time.UTC)
+	}
+
+	// a tx in each block from 1 to 45
+	var queries []string
+	var headerRequests, blockRequests int
+	param := func(req rpctypes.RPCRequest, key string) int {
+		var params map[string]interface{}
+		_ = json.Unmarshal(req.Params, &params)
+		v, _ := strconv.Atoi(fmt.Sprint(params[key]))
+		return v
+	}
+	node := rpctest.NewNode(t, map[string]func(rpctypes.RPCRequest) rpctypes.RPCResponse{
+		"status": rpctest.Result(&ctypes.ResultStatus{
+			NodeInfo: p2p.DefaultNodeInfo{Network: "chaintest-1"},
+			SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 45},
+		}),
+		"tx_search": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			var params map[string]interface{}
+			_ = json.Unmarshal(req.Params, &params)
+			queries = append(queries, fmt.Sprint(params["query"], " ", params["order_by"]))
+			result := &ctypes.ResultTxSearch{TotalCount: 45}
+			page, perPage := param(req, "page"), param(req, "per_page")
+			if page == 0 {
+				page = 1
+			}
+			for i := 0; i < perPage; i++ {
+				height := int64(45 - (page-1)*perPage - i)
+				if height < 1 {
+					break
+				}
+				result.Txs = append(result.Txs, &ctypes.ResultTx{Height: height, Tx: txBytes})
+			}
+			return rpctypes.NewRPCSuccessResponse(req.ID, result)
+		},
+		"blockchain": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			headerRequests++
+			result := &ctypes.ResultBlockchainInfo{LastHeight: 45}
+			for height := int64(param(req, "maxHeight")); height >= int64(param(req, "minHeight")); height-- {
+				if height <= 45 {
+					result.BlockMetas = append(result.BlockMetas, &tmtypes.BlockMeta{
+						Header: tmtypes.Header{Height: height, Time: blockTime(height)},
+					})
+				}
+			}
+			return rpctypes.NewRPCSuccessResponse(req.ID, result)
+		},
+		"block": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			blockRequests++
+			return rpctypes.NewRPCErrorResponse(req.ID, -32603, "Internal error", "unexpected block request")
+		},
+	})
+
+	pool, err := NewRPCPool([]string{node.URL}, "chaintest-1", nil, nil, log.NewNopLogger(), 1, 0)
+	require.NoError(t, err)
+	defer pool.Stop()
+	base := baseClient{
+		TmClient:       pool,
+		logger:         log.NewNopLogger(),
+		encodingConfig: sdk.EncodingConfig{TxConfig: txConfig},
+		blockTimeCache: cache.NewCache(blockTimeCacheCapacity, true),
+	}
+
+	// every page is fetched in the descending order up to the latest height
+	builder := sdk.NewEventQueryBuilder().AddCondition(sdk.NewCond("message", "sender").EQ("plug1"))
+	it := base.IterateTxs(context.Background(), builder, sdk.TxOrderDesc, 20)
+	var heights []int64
+	for it.Next() {
+		require.Equal(t, blockTime(it.Tx().Height).Format(time.RFC3339), it.Tx().Timestamp)
+		heights = append(heights, it.Tx().Height)
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, 45, it.Total())
+	require.Len(t, heights, 45)
+	require.Equal(t, int64(45), heights[0])
+	require.Equal(t, int64(1), heights[44])
+	require.Equal(t, []string{
+		"message.sender='plug1' AND tx.height<=45 desc",
+		"message.sender='plug1' AND tx.height<=45 desc",
+		"message.sender='plug1' AND tx.height<=45 desc",
+	}, queries)
+	require.Equal(t, "message.sender='plug1'", builder.Build())
+
+	// the times of the blocks are fetched by ranges of headers, then cached
+	require.Equal(t, 3, headerRequests)
+	_, err = base.SearchTxs(builder, sdk.TxSearchRequest{Size: 20, Order: sdk.TxOrderDesc})
+	require.NoError(t, err)
+	require.Equal(t, 3, headerRequests)
+	require.Equal(t, 0, blockRequests)
+}
+
+func TestQueryHeight(t *testing.T) {
+	// the node runs the queries at the height requested, at 120 if none
+	var heights []int
+	node := rpctest.NewNode(t, map[string]func(rpctypes.RPCRequest) rpctypes.RPCResponse{
+		"status": rpctest.Result(&ctypes.ResultStatus{NodeInfo: p2p.DefaultNodeInfo{Network: "chaintest-1"}}),
+		"abci_query": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			var params map[string]interface{}
+			_ = json.Unmarshal(req.Params, &params)
+			height, _ := strconv.Atoi(fmt.Sprint(params["height"]))
+			heights = append(heights, height)
+			if height == 0 {
+				height = 120
+			}
+			return rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultABCIQuery{
+				Response: abci.ResponseQuery{Height: int64(height), Value: []byte("value")},
+			})
+		},
+	})
+
+	pool, err := NewRPCPool([]string{node.URL}, "chaintest-1", nil, nil, log.NewNopLogger(), 1, 0)
+	require.NoError(t, err)
+	defer pool.Stop()
+	base := baseClient{TmClient: pool, logger: log.NewNopLogger(), cfg: &sdk.ClientConfig{QueryHeight: 100}}
+
+	ctx, record := sdk.ContextWithHeightRecord(context.Background())
+	_, err = base.QueryContext(ctx, "/custom/test", nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), record.Height())
+
+	ctx, record = sdk.ContextWithHeightRecord(sdk.ContextWithHeight(context.Background(), 80))
+	_, err = base.QueryContext(ctx, "/custom/test", nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(80), record.Height())
+
+	ctx, record = sdk.ContextWithHeightRecord(sdk.ContextWithHeight(context.Background(), 0))
+	_, err = base.QueryContext(ctx, "/custom/test", nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(120), record.Height())
+
+	// the store queries at height 0 are pinned the same way
+	ctx, record = sdk.ContextWithHeightRecord(context.Background())
+	_, err = base.QueryStoreContext(ctx, []byte("key"), "bank", 0, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), record.Height())
+
+	_, err = base.QueryStoreContext(ctx, []byte("key"), "bank", 90, false)
+	require.NoError(t, err)
+	require.Equal(t, int64(90), record.Height())
+	require.Equal(t, []int{100, 80, 0, 100, 90}, heights)
+
+	// the grpc queries send the height as metadata and record the height of the header returned
+	interceptor := queryHeightInterceptor(100)
+	invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		height := "120"
+		if values := md.Get(sdk.GRPCBlockHeightHeader); len(values) > 0 {
+			height = values[0]
+		}
+		for _, opt := range opts {
+			if header, ok := opt.(grpc.HeaderCallOption); ok {
+				*header.HeaderAddr = metadata.Pairs(sdk.GRPCBlockHeightHeader, height)
+			}
+		}
+		return nil
+	}
+	ctx, record = sdk.ContextWithHeightRecord(context.Background())
+	require.NoError(t, interceptor(ctx, "/test", nil, nil, nil, invoker))
+	require.Equal(t, int64(100), record.Height())
+
+	ctx, record = sdk.ContextWithHeightRecord(sdk.ContextWithHeight(context.Background(), 0))
+	require.NoError(t, interceptor(ctx, "/test", nil, nil, nil, invoker))
+	require.Equal(t, int64(120), record.Height())
+}
+
+// newStubTxNode starts a tendermint node answering the tx queries with query, its websocket
+// publishes event to the subscriptions if it is not nil
+func newStubTxNode(t *testing.T, query func() (*ctypes.ResultTx, error), event *tmtypes.EventDataTx) *httptest.Server {
+	handler := rpctest.NewHandler(map[string]func(rpctypes.RPCRequest) rpctypes.RPCResponse{
+		"status": rpctest.Result(&ctypes.ResultStatus{NodeInfo: p2p.DefaultNodeInfo{Network: "chaintest-1"}}),
+		"tx": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			tx, err := query()
+			if err != nil {
+				return rpctypes.NewRPCErrorResponse(req.ID, -32603, "Internal error", err.Error())
+			}
+			return rpctypes.NewRPCSuccessResponse(req.ID, tx)
+		},
+		"blockchain": func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+			var params map[string]interface{}
+			_ = json.Unmarshal(req.Params, &params)
+			height, _ := strconv.ParseInt(fmt.Sprint(params["minHeight"]), 10, 64)
+			return rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultBlockchainInfo{
+				LastHeight: height,
+				BlockMetas: []*tmtypes.BlockMeta{{Header: tmtypes.Header{Height: height, Time: time.Now()}}},
+			})
+		},
+	})
+
+	upgrader := websocket.Upgrader{}
+	node := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/websocket" {
+			handler.ServeHTTP(w, r)
+			return
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		for {
+			var req rpctypes.RPCRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			var params struct {
+				Query string `json:"query"`
+			}
+			_ = json.Unmarshal(req.Params, &params)
+
+			_ = conn.WriteJSON(rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultSubscribe{}))
+			if req.Method == "subscribe" && event != nil {
+				_ = conn.WriteJSON(rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultEvent{Query: params.Query, Data: *event}))
+			}
+		}
+	}))
+	t.Cleanup(node.Close)
+	return node
+}
+
+func TestWaitForTx(t *testing.T) {
+	txConfig := txtypes.NewTxConfig(codec.NewProtoCodec(cdctypes.NewInterfaceRegistry()), txtypes.DefaultSignModes)
+	txBytes, err := txConfig.TxEncoder()(txConfig.NewTxBuilder().GetTx())
+	require.NoError(t, err)
+	hash := fmt.Sprintf("%X", tmtypes.Tx(txBytes).Hash())
+	notFound := fmt.Errorf("tx (%s) not found", hash)
+
+	waitForTx := func(node *httptest.Server, timeout time.Duration) (sdk.ResultTx, sdk.Error) {
+		pool, err := NewRPCPool([]string{node.URL}, "chaintest-1", nil, txConfig.TxDecoder(), log.NewNopLogger(), 1, 0)
+		require.NoError(t, err)
+		defer pool.Stop()
+
+		base := baseClient{
+			TmClient:       pool,
+			logger:         log.NewNopLogger(),
+			cfg:            &sdk.ClientConfig{},
+			encodingConfig: sdk.EncodingConfig{TxConfig: txConfig},
+			blockTimeCache: cache.NewCache(blockTimeCacheCapacity, true),
+		}
+		return base.WaitForTx(hash, timeout)
+	}
+
+	t.Run("polling", func(t *testing.T) {
+		// the tx is included after the first query
+		var queries int
+		node := newStubTxNode(t, func() (*ctypes.ResultTx, error) {
+			if queries++; queries == 1 {
+				return nil, notFound
+			}
+			return &ctypes.ResultTx{
+				Hash:     tmtypes.Tx(txBytes).Hash(),
+				Height:   7,
+				Tx:       txBytes,
+				TxResult: abci.ResponseDeliverTx{GasWanted: 200000, GasUsed: 81234},
+			}, nil
+		}, nil)
+
+		res, err := waitForTx(node, 5*time.Second)
+		require.NoError(t, err)
+		require.Equal(t, 2, queries)
+		require.Equal(t, hash, res.Hash)
+		require.Equal(t, int64(7), res.Height)
+		require.Equal(t, int64(200000), res.GasWanted)
+		require.Equal(t, int64(81234), res.GasUsed)
+		require.Equal(t, uint32(0), res.Code)
+	})
+
+	t.Run("subscription", func(t *testing.T) {
+		// the tx is published to the tx.hash subscription only, failed in DeliverTx
+		node := newStubTxNode(t, func() (*ctypes.ResultTx, error) { return nil, notFound }, &tmtypes.EventDataTx{
+			TxResult: abci.TxResult{
+				Height: 9,
+				Tx:     txBytes,
+				Result: abci.ResponseDeliverTx{Code: 5, Codespace: sdk.RootCodespace, Log: "insufficient funds", GasWanted: 200000, GasUsed: 64000},
+			},
+		})
+
+		res, err := waitForTx(node, 5*time.Second)
+		require.Error(t, err)
+		require.True(t, errors.Is(err, sdk.ErrInsufficientFunds))
+		require.Equal(t, hash, res.Hash)
+		require.Equal(t, int64(9), res.Height)
+		require.Equal(t, int64(200000), res.GasWanted)
+		require.Equal(t, int64(64000), res.GasUsed)
+		require.Equal(t, uint32(5), res.Code)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		node := newStubTxNode(t, func() (*ctypes.ResultTx, error) { return nil, notFound }, nil)
+		_, err := waitForTx(node, 100*time.Millisecond)
+		require.IsType(t, sdk.TxTimeoutError{}, err)
+		require.Equal(t, sdk.ClientCodespace, err.Codespace())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		// a failing node is not reported as a timeout
+		node := newStubTxNode(t, func() (*ctypes.ResultTx, error) { return nil, errors.New("database is closed") }, nil)
+		_, err := waitForTx(node, 5*time.Second)
+		_, timedOut := err.(sdk.TxTimeoutError)
+		require.False(t, timedOut)
+		require.Contains(t, err.Error(), "database is closed")
+	})
+}
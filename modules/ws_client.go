@@ -0,0 +1,492 @@
+package modules
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	tmjson "github.com/tendermint/tendermint/libs/json"
+	"github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+
+	sdk "plugchain-sdk-go/types"
+)
+
+const (
+	wsEndpoint = "/websocket"
+	// wsPingPeriod is how often the node is pinged, a websocket silent for wsReadWait is considered lost
+	wsPingPeriod = 20 * time.Second
+	wsReadWait   = 30 * time.Second
+	wsWriteWait  = 10 * time.Second
+
+	wsMinBackoff           = time.Second
+	wsMaxBackoff           = 30 * time.Second
+	wsMaxReconnectAttempts = 20
+)
+
+var errWSStopped = errors.New("websocket client is stopped")
+
+// wsClient carries the subscriptions to a node over a single websocket. A lost websocket is
+// dialed again with an exponential backoff, and every subscription is sent again once it is connected.
+type wsClient struct {
+	log.Logger
+	remote      string
+	url         string
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+
+	mtx      sync.Mutex
+	conn     *websocket.Conn
+	running  bool
+	stopped  bool
+	subs     map[string]*wsSubscription
+	pending  map[int]chan error
+	nextID   int
+	onStatus sdk.WSStatusHandler
+	quit     chan struct{}
+
+	writeMtx sync.Mutex
+}
+
+type wsSubscription struct {
+	id      string
+	query   string
+	handler func(event ctypes.ResultEvent)
+	// closed is called once the subscription is removed, with the error which caused it if any
+	closed func(err error)
+}
+
+func newWSClient(remote string, logger log.Logger) (*wsClient, error) {
+	u, err := wsURL(remote)
+	if err != nil {
+		return nil, err
+	}
+	return &wsClient{
+		Logger:      logger,
+		remote:      remote,
+		url:         u,
+		maxAttempts: wsMaxReconnectAttempts,
+		minBackoff:  wsMinBackoff,
+		maxBackoff:  wsMaxBackoff,
+		subs:        make(map[string]*wsSubscription),
+		pending:     make(map[int]chan error),
+		quit:        make(chan struct{}),
+	}, nil
+}
+
+// wsURL returns the websocket endpoint of a tendermint rpc address
+func wsURL(remote string) (string, error) {
+	if !strings.Contains(remote, "://") {
+		remote = "tcp://" + remote
+	}
+	u, err := url.Parse(remote)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "https", "wss":
+		u.Scheme = "wss"
+	default:
+		u.Scheme = "ws"
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + wsEndpoint
+	return u.String(), nil
+}
+
+func (w *wsClient) setStatusHandler(handler sdk.WSStatusHandler) {
+	w.mtx.Lock()
+	w.onStatus = handler
+	w.mtx.Unlock()
+}
+
+// subscribe registers the handler of the query, a subscription added while reconnecting
+// is sent to the node once the websocket is connected again
+func (w *wsClient) subscribe(ctx context.Context, subscriber, query string,
+	handler func(event ctypes.ResultEvent), closed func(err error)) error {
+	if err := w.connect(); err != nil {
+		return err
+	}
+
+	w.mtx.Lock()
+	subscribed := w.hasQuery(query)
+	w.subs[subscriber] = &wsSubscription{
+		id:      subscriber,
+		query:   query,
+		handler: handler,
+		closed:  closed,
+	}
+	connected := w.conn != nil
+	w.mtx.Unlock()
+
+	if subscribed || !connected {
+		return nil
+	}
+	if err := w.call(ctx, "subscribe", query); err != nil {
+		w.mtx.Lock()
+		delete(w.subs, subscriber)
+		w.mtx.Unlock()
+		return err
+	}
+	return nil
+}
+
+func (w *wsClient) unsubscribe(ctx context.Context, subscriber string) error {
+	w.mtx.Lock()
+	sub, ok := w.subs[subscriber]
+	delete(w.subs, subscriber)
+	last := ok && !w.hasQuery(sub.query)
+	connected := w.conn != nil
+	w.mtx.Unlock()
+
+	if !ok {
+		return fmt.Errorf("subscription %s not found", subscriber)
+	}
+	sub.closed(nil)
+	// the query is not sent again after a reconnect
+	if !last || !connected {
+		return nil
+	}
+	return w.call(ctx, "unsubscribe", sub.query)
+}
+
+func (w *wsClient) hasSubscription(subscriber string) bool {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	_, ok := w.subs[subscriber]
+	return ok
+}
+
+// hasQuery returns whether a subscription of the query exists, w.mtx must be held
+func (w *wsClient) hasQuery(query string) bool {
+	for _, sub := range w.subs {
+		if sub.query == query {
+			return true
+		}
+	}
+	return false
+}
+
+// connect dials the websocket unless it is already connected or reconnecting
+func (w *wsClient) connect() error {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+
+	if w.stopped {
+		return errWSStopped
+	}
+	if w.running {
+		return nil
+	}
+
+	conn, err := w.dial()
+	if err != nil {
+		return err
+	}
+	w.running = true
+	go w.run(w.serve(conn))
+	return nil
+}
+
+func (w *wsClient) dial() (*websocket.Conn, error) {
+	dialer := websocket.Dialer{
+		Proxy:            http.ProxyFromEnvironment,
+		HandshakeTimeout: wsReadWait,
+	}
+	conn, _, err := dialer.Dial(w.url, nil) // nolint:bodyclose
+	return conn, err
+}
+
+// serve reads and pings the websocket, the returned channel receives the error which ended it.
+// w.mtx must be held.
+func (w *wsClient) serve(conn *websocket.Conn) <-chan error {
+	w.conn = conn
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsReadWait))
+	})
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(wsPingPeriod)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteWait)); err != nil {
+					return
+				}
+			}
+		}
+	}()
+
+	errc := make(chan error, 1)
+	go func() {
+		err := w.read(conn)
+		close(done)
+		_ = conn.Close()
+
+		w.mtx.Lock()
+		if w.conn == conn {
+			w.conn = nil
+		}
+		// the requests waiting for a response will not get one
+		for _, ch := range w.pending {
+			select {
+			case ch <- err:
+			default:
+			}
+		}
+		w.mtx.Unlock()
+		errc <- err
+	}()
+	return errc
+}
+
+func (w *wsClient) read(conn *websocket.Conn) error {
+	for {
+		if err := conn.SetReadDeadline(time.Now().Add(wsReadWait)); err != nil {
+			return err
+		}
+		var res rpctypes.RPCResponse
+		if err := conn.ReadJSON(&res); err != nil {
+			return err
+		}
+		if err := w.handle(res); err != nil {
+			return err
+		}
+	}
+}
+
+// handle dispatches an event to its subscriptions or delivers the response of a request
+func (w *wsClient) handle(res rpctypes.RPCResponse) error {
+	if res.Error == nil {
+		var event ctypes.ResultEvent
+		if err := tmjson.Unmarshal(res.Result, &event); err == nil && len(event.Query) > 0 {
+			w.dispatch(event)
+			return nil
+		}
+	}
+
+	if id, ok := res.ID.(rpctypes.JSONRPCIntID); ok {
+		w.mtx.Lock()
+		ch, ok := w.pending[int(id)]
+		w.mtx.Unlock()
+		if ok {
+			var err error
+			if res.Error != nil {
+				err = res.Error
+			}
+			select {
+			case ch <- err:
+			default:
+			}
+			return nil
+		}
+	}
+
+	if res.Error != nil {
+		// the node cancels a subscription whose events are not read fast enough,
+		// the subscriptions are restored by reconnecting
+		return res.Error
+	}
+	return nil
+}
+
+func (w *wsClient) dispatch(event ctypes.ResultEvent) {
+	w.mtx.Lock()
+	var handlers []func(event ctypes.ResultEvent)
+	for _, sub := range w.subs {
+		if sub.query == event.Query {
+			handlers = append(handlers, sub.handler)
+		}
+	}
+	w.mtx.Unlock()
+
+	for _, handler := range handlers {
+		handler(event)
+	}
+}
+
+// call sends a request over the websocket and waits for its response
+func (w *wsClient) call(ctx context.Context, method, query string) error {
+	ctx, cancel := context.WithTimeout(ctx, wsReadWait)
+	defer cancel()
+
+	w.mtx.Lock()
+	conn := w.conn
+	if conn == nil {
+		w.mtx.Unlock()
+		return errors.New("websocket is not connected")
+	}
+	w.nextID++
+	id := w.nextID
+	res := make(chan error, 1)
+	w.pending[id] = res
+	w.mtx.Unlock()
+
+	defer func() {
+		w.mtx.Lock()
+		delete(w.pending, id)
+		w.mtx.Unlock()
+	}()
+
+	req, err := rpctypes.MapToRequest(rpctypes.JSONRPCIntID(id), method, map[string]interface{}{"query": query})
+	if err != nil {
+		return err
+	}
+
+	w.writeMtx.Lock()
+	_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+	err = conn.WriteJSON(req)
+	w.writeMtx.Unlock()
+	if err != nil {
+		return err
+	}
+
+	select {
+	case err := <-res:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run reconnects the websocket whenever it is lost, until the client is stopped or the attempts are exhausted
+func (w *wsClient) run(errc <-chan error) {
+	for {
+		err := <-errc
+		if w.isStopped() {
+			return
+		}
+
+		w.Error("websocket lost, reconnecting", "node", w.remote, "errMsg", err.Error())
+		w.notify(sdk.WSReconnecting, err)
+
+		if errc, err = w.reconnect(); err != nil {
+			if err == errWSStopped {
+				return
+			}
+			w.giveUp(err)
+			return
+		}
+		w.Info("websocket reconnected", "node", w.remote)
+		w.notify(sdk.WSConnected, nil)
+	}
+}
+
+func (w *wsClient) reconnect() (<-chan error, error) {
+	var err error
+	backoff := w.minBackoff
+	for attempt := 1; w.maxAttempts < 0 || attempt <= w.maxAttempts; attempt++ {
+		select {
+		case <-w.quit:
+			return nil, errWSStopped
+		case <-time.After(backoff):
+		}
+		if backoff *= 2; backoff > w.maxBackoff {
+			backoff = w.maxBackoff
+		}
+
+		var conn *websocket.Conn
+		if conn, err = w.dial(); err != nil {
+			w.Debug("reconnect websocket failed", "node", w.remote, "attempt", attempt, "errMsg", err.Error())
+			continue
+		}
+
+		w.mtx.Lock()
+		if w.stopped {
+			w.mtx.Unlock()
+			_ = conn.Close()
+			return nil, errWSStopped
+		}
+		errc := w.serve(conn)
+		w.mtx.Unlock()
+
+		if err = w.resubscribe(); err != nil {
+			w.Debug("resubscribe failed", "node", w.remote, "attempt", attempt, "errMsg", err.Error())
+			_ = conn.Close()
+			<-errc
+			continue
+		}
+		return errc, nil
+	}
+	return nil, err
+}
+
+// resubscribe sends every query again on a new websocket
+func (w *wsClient) resubscribe() error {
+	w.mtx.Lock()
+	queries := make(map[string]bool)
+	for _, sub := range w.subs {
+		queries[sub.query] = true
+	}
+	w.mtx.Unlock()
+
+	for query := range queries {
+		if err := w.call(context.Background(), "subscribe", query); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// giveUp removes the subscriptions, the next subscription dials the websocket again
+func (w *wsClient) giveUp(err error) {
+	w.mtx.Lock()
+	w.running = false
+	subs := w.subs
+	w.subs = make(map[string]*wsSubscription)
+	w.mtx.Unlock()
+
+	w.Error("websocket reconnect attempts exhausted, subscriptions removed", "node", w.remote, "errMsg", err.Error())
+	for _, sub := range subs {
+		sub.closed(err)
+	}
+	w.notify(sdk.WSGaveUp, err)
+}
+
+func (w *wsClient) notify(status sdk.WSStatus, err error) {
+	w.mtx.Lock()
+	fn := w.onStatus
+	w.mtx.Unlock()
+
+	if fn != nil {
+		fn(w.remote, status, err)
+	}
+}
+
+func (w *wsClient) isStopped() bool {
+	w.mtx.Lock()
+	defer w.mtx.Unlock()
+	return w.stopped
+}
+
+// stop closes the websocket without reconnecting it and ends the subscriptions
+func (w *wsClient) stop() {
+	w.mtx.Lock()
+	if w.stopped {
+		w.mtx.Unlock()
+		return
+	}
+	w.stopped = true
+	close(w.quit)
+	if w.conn != nil {
+		_ = w.conn.Close()
+	}
+	subs := w.subs
+	w.subs = make(map[string]*wsSubscription)
+	w.mtx.Unlock()
+
+	for _, sub := range subs {
+		sub.closed(nil)
+	}
+}
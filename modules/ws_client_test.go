@@ -0,0 +1,112 @@
+package modules
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/libs/log"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "plugchain-sdk-go/types"
+)
+
+// newStubWSNode starts a tendermint websocket answering subscribe with one event,
+// the accepted connections are sent on conns so that the test can drop them
+func newStubWSNode(t *testing.T) (server *httptest.Server, conns <-chan *websocket.Conn, queries <-chan string) {
+	connc := make(chan *websocket.Conn, 10)
+	queryc := make(chan string, 10)
+	var height int64
+	upgrader := websocket.Upgrader{}
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+		connc <- conn
+
+		for {
+			var req rpctypes.RPCRequest
+			if err := conn.ReadJSON(&req); err != nil {
+				return
+			}
+			var params struct {
+				Query string `json:"query"`
+			}
+			if err := json.Unmarshal(req.Params, &params); err != nil {
+				return
+			}
+
+			_ = conn.WriteJSON(rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultSubscribe{}))
+			if req.Method != "subscribe" {
+				continue
+			}
+			queryc <- params.Query
+			_ = conn.WriteJSON(rpctypes.NewRPCSuccessResponse(req.ID, &ctypes.ResultEvent{
+				Query: params.Query,
+				Data: tmtypes.EventDataNewBlockHeader{
+					Header: tmtypes.Header{Height: atomic.AddInt64(&height, 1)},
+				},
+			}))
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, connc, queryc
+}
+
+func TestWSClientReconnect(t *testing.T) {
+	server, conns, queries := newStubWSNode(t)
+
+	ws, err := newWSClient(server.URL, log.NewNopLogger())
+	require.NoError(t, err)
+	defer ws.stop()
+	ws.minBackoff = 10 * time.Millisecond
+	ws.maxAttempts = 2
+
+	statuses := make(chan sdk.WSStatus, 10)
+	ws.setStatusHandler(func(node string, status sdk.WSStatus, err error) {
+		statuses <- status
+	})
+
+	heights := make(chan int64, 10)
+	query := tmtypes.QueryForEvent(tmtypes.EventNewBlockHeader).String()
+	err = ws.subscribe(context.Background(), "subscriber", query, func(event ctypes.ResultEvent) {
+		heights <- event.Data.(tmtypes.EventDataNewBlockHeader).Header.Height
+	}, func(err error) {})
+	require.NoError(t, err)
+	require.Equal(t, query, receive(t, queries))
+	require.Equal(t, int64(1), receive(t, heights))
+
+	// the websocket dropped by the node is reconnected and the query subscribed again
+	_ = receive(t, conns).(*websocket.Conn).Close()
+	require.Equal(t, sdk.WSReconnecting, receive(t, statuses))
+	require.Equal(t, sdk.WSConnected, receive(t, statuses))
+	require.Equal(t, query, receive(t, queries))
+	require.Equal(t, int64(2), receive(t, heights))
+
+	// the subscriptions are removed once the node can not be reached anymore
+	server.Close()
+	_ = receive(t, conns).(*websocket.Conn).Close()
+	require.Equal(t, sdk.WSReconnecting, receive(t, statuses))
+	require.Equal(t, sdk.WSGaveUp, receive(t, statuses))
+	require.Error(t, ws.unsubscribe(context.Background(), "subscriber"))
+}
+
+// receive returns the next value of the channel, the test fails after a timeout
+func receive(t *testing.T, ch interface{}) interface{} {
+	chosen, v, _ := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(ch)},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(time.After(5 * time.Second))},
+	})
+	require.Equal(t, 0, chosen, "timeout")
+	return v.Interface()
+}
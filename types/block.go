@@ -9,6 +9,7 @@ import (
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"plugchain-sdk-go/codec"
+	"plugchain-sdk-go/types/store"
 )
 
 type Block struct {
@@ -49,6 +50,40 @@ type BlockDetail struct {
 	BlockResult BlockResult     `json:"block_result"`
 }
 
+// FollowedBlock is a block delivered by FollowBlocks with its txs decoded, in the order of the block
+type FollowedBlock struct {
+	BlockID    tmtypes.BlockID  `json:"block_id"`
+	Header     tmtypes.Header   `json:"header"`
+	Txs        []BlockTx        `json:"txs"`
+	BeginBlock ResultBeginBlock `json:"begin_block"`
+	EndBlock   ResultEndBlock   `json:"end_block"`
+}
+
+// BlockTx is a tx of a block with its result, Tx is nil if the bytes can not be decoded
+type BlockTx struct {
+	Hash   string   `json:"hash"`
+	Index  uint32   `json:"index"`
+	Bytes  []byte   `json:"bytes"`
+	Tx     Tx       `json:"-"`
+	Result TxResult `json:"result"`
+}
+
+// FollowOptions configures FollowBlocks
+type FollowOptions struct {
+	// Name is the key of the height saved in Checkpoint, "default" if empty
+	Name string
+	// StartHeight is the first height followed when no height is saved, the latest height if 0
+	StartHeight int64
+	// Concurrency is the number of blocks fetched in parallel to catch up, default 4
+	Concurrency int
+	// Checkpoint persists the last height handled, the follower resumes from the next one
+	Checkpoint store.Checkpoint
+}
+
+// BlockHandler processes a block of FollowBlocks, the follower stops with the returned error
+// and the block is delivered again when it resumes
+type BlockHandler func(block FollowedBlock) error
+
 type ABCIResponses struct {
 	DeliverTx  []TxResult
 	EndBlock   ResultEndBlock
@@ -86,11 +121,12 @@ func ParseBlockResult(res *ctypes.ResultBlockResults) BlockResult {
 	var txResults = make([]TxResult, len(res.TxsResults))
 	for i, r := range res.TxsResults {
 		txResults[i] = TxResult{
-			Code:      r.Code,
-			Log:       r.Log,
-			GasWanted: r.GasWanted,
-			GasUsed:   r.GasUsed,
-			Events:    StringifyEvents(r.Events),
+			Code:       r.Code,
+			Log:        r.Log,
+			GasWanted:  r.GasWanted,
+			GasUsed:    r.GasUsed,
+			Events:     StringifyEvents(r.Events),
+			ABCIEvents: r.Events,
 		}
 	}
 	return BlockResult{
@@ -1,10 +1,15 @@
 package types
 
 import (
+	"context"
+	"time"
+
 	"google.golang.org/grpc"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
+
+	"plugchain-sdk-go/types/store"
 )
 
 type TxManager interface {
@@ -14,6 +19,48 @@ type TxManager interface {
 	BuildAndSign(msg []Msg, baseTx BaseTx) ([]byte, Error)
 	SendBatch(msgs Msgs, baseTx BaseTx) ([]ResultTx, Error)
 	BuildAndSendWithAccount(addr string, accountNumber, sequence uint64, msg []Msg, baseTx BaseTx) (ResultTx, Error)
+	// BroadcastAndWait sends the tx and waits until it is included in a block
+	BroadcastAndWait(msg []Msg, baseTx BaseTx, timeout time.Duration) (ResultTx, Error)
+	// WaitForTx waits until the tx is included in a block, a TxTimeoutError is returned after timeout
+	WaitForTx(hash string, timeout time.Duration) (ResultTx, Error)
+	// BuildUnsignedTx builds the tx of the account at addr without any key, see UnsignedTx
+	BuildUnsignedTx(addr string, msg []Msg, baseTx BaseTx) ([]byte, Error)
+	// SignTx signs an UnsignedTx offline with a key of the KeyDAO and returns the signed tx in JSON
+	SignTx(unsignedTx []byte, name, password string) ([]byte, Error)
+	// BroadcastSignedTx broadcasts a signed tx encoded in JSON or in bytes
+	BroadcastSignedTx(signedTx []byte, mode BroadcastMode) (ResultTx, Error)
+	// NewMultisigPubKey returns the k-of-n multisig key of pubKeys and its address
+	NewMultisigPubKey(threshold int, pubKeys []TmPubKey) (TmPubKey, string, Error)
+	// SignMultisigTx returns the partial signature of an UnsignedTx of a multisig account in JSON
+	SignMultisigTx(unsignedTx []byte, name, password string) ([]byte, Error)
+	// MultisignTx merges the partial signatures of an UnsignedTx and returns the signed tx in JSON
+	MultisignTx(unsignedTx []byte, multisigPubKey TmPubKey, signatures ...[]byte) ([]byte, Error)
+	// Simulate runs the msgs on the node without sending them and returns the complete result
+	Simulate(msg []Msg, baseTx BaseTx) (ResultSimulateTx, Error)
+	// OutboxTxs returns the txs of the outbox with the status, see OutboxOption
+	OutboxTxs(status store.OutboxStatus) ([]store.OutboxEntry, Error)
+	// RemoveOutboxTx removes a tx from the outbox
+	RemoveOutboxTx(hash string) Error
+	// ResubmitOutbox confirms the pending txs of the outbox and broadcasts again those not included
+	ResubmitOutbox() ([]store.OutboxEntry, Error)
+	// NewTx returns a builder collecting the msgs of any modules to be sent in one tx
+	NewTx() *MultiMsgTx
+	// BatchSize returns how many of the leading msgs fit in a tx according to the consensus params of the node
+	BatchSize(ctx context.Context, msgs []Msg) int
+
+	// The following methods are the same as the above, but they pass ctx
+	// to the node, so that the caller can cancel the request or set a deadline.
+	BuildTxHashContext(ctx context.Context, msg []Msg, baseTx BaseTx) (string, Error)
+	BuildAndSendContext(ctx context.Context, msg []Msg, baseTx BaseTx) (ResultTx, Error)
+	BuildAndSignContext(ctx context.Context, msg []Msg, baseTx BaseTx) ([]byte, Error)
+	SendBatchContext(ctx context.Context, msgs Msgs, baseTx BaseTx) ([]ResultTx, Error)
+	BuildAndSendWithAccountContext(ctx context.Context, addr string, accountNumber, sequence uint64, msg []Msg, baseTx BaseTx) (ResultTx, Error)
+	BroadcastAndWaitContext(ctx context.Context, msg []Msg, baseTx BaseTx, timeout time.Duration) (ResultTx, Error)
+	WaitForTxContext(ctx context.Context, hash string, timeout time.Duration) (ResultTx, Error)
+	BuildUnsignedTxContext(ctx context.Context, addr string, msg []Msg, baseTx BaseTx) ([]byte, Error)
+	BroadcastSignedTxContext(ctx context.Context, signedTx []byte, mode BroadcastMode) (ResultTx, Error)
+	SimulateContext(ctx context.Context, msg []Msg, baseTx BaseTx) (ResultSimulateTx, Error)
+	ResubmitOutboxContext(ctx context.Context) ([]store.OutboxEntry, Error)
 }
 
 type Queries interface {
@@ -23,7 +70,10 @@ type Queries interface {
 }
 
 type GRPCClient interface {
+	// GenConn returns a shared connection, it must not be closed by the caller
 	GenConn() (*grpc.ClientConn, error)
+	// Close closes all the connections held by the client
+	Close() error
 }
 
 type ParamQuery interface {
@@ -34,27 +84,51 @@ type StoreQuery interface {
 	QueryWithResponse(path string, data interface{}, result Response) error
 	Query(path string, data interface{}) ([]byte, error)
 	QueryStore(key HexBytes, storeName string, height int64, prove bool) (abci.ResponseQuery, error)
+
+	QueryWithResponseContext(ctx context.Context, path string, data interface{}, result Response) error
+	QueryContext(ctx context.Context, path string, data interface{}) ([]byte, error)
+	QueryStoreContext(ctx context.Context, key HexBytes, storeName string, height int64, prove bool) (abci.ResponseQuery, error)
 }
 
 type AccountQuery interface {
 	QueryAccount(address string) (BaseAccount, Error)
 	QueryAddress(name, password string) (AccAddress, Error)
+
+	QueryAccountContext(ctx context.Context, address string) (BaseAccount, Error)
 }
 
 type TmQuery interface {
 	QueryTx(hash string) (ResultQueryTx, error)
 	QueryTxs(builder *EventQueryBuilder, page, size *int) (ResultSearchTxs, error)
+	// SearchTxs returns a page of the txs matching the query, in the order of the request
+	SearchTxs(builder *EventQueryBuilder, req TxSearchRequest) (ResultSearchTxs, error)
+	// IterateTxs returns an iterator over the txs of every page matching the query, the txs of the
+	// blocks committed during the walk are not returned
+	IterateTxs(ctx context.Context, builder *EventQueryBuilder, order TxOrder, size int) *TxIterator
 	QueryBlock(height int64) (BlockDetail, error)
+	// FollowBlocks delivers every block to handler in order, from the height saved in the checkpoint
+	// or opts.StartHeight, catching up with the past blocks then following the new ones until ctx is done
+	FollowBlocks(ctx context.Context, opts FollowOptions, handler BlockHandler) Error
+
+	QueryTxContext(ctx context.Context, hash string) (ResultQueryTx, error)
+	QueryTxsContext(ctx context.Context, builder *EventQueryBuilder, page, size *int) (ResultSearchTxs, error)
+	SearchTxsContext(ctx context.Context, builder *EventQueryBuilder, req TxSearchRequest) (ResultSearchTxs, error)
+	QueryBlockContext(ctx context.Context, height int64) (BlockDetail, error)
 }
 
 type TokenManager interface {
 	QueryToken(denom string) (Token, error)
 	SaveTokens(tokens ...Token)
+
+	QueryTokenContext(ctx context.Context, denom string) (Token, error)
 }
 
 type TokenConvert interface {
 	ToMinCoin(coin ...DecCoin) (Coins, Error)
 	ToMainCoin(coin ...Coin) (DecCoins, Error)
+
+	ToMinCoinContext(ctx context.Context, coin ...DecCoin) (Coins, Error)
+	ToMainCoinContext(ctx context.Context, coin ...Coin) (DecCoins, Error)
 }
 
 type Logger interface {
@@ -75,6 +75,20 @@ func (ct CoinType) ConvertToMinCoin(coin DecCoin) (newCoin Coin, err error) {
 	return NewCoin(ct.MinUnit.Denom, amt.RoundInt()), nil
 }
 
+//ConvertToMinDecCoin return the min denom coin from args without rounding, used to convert prices
+func (ct CoinType) ConvertToMinDecCoin(coin DecCoin) (DecCoin, error) {
+	if !ct.hasUnit(coin.Denom) || ct.isMinUnit(coin.Denom) {
+		return coin, nil
+	}
+
+	// dest amount = src amount * (10^(dest scale) / 10^(src scale))
+	srcScale := NewDecFromInt(ct.MainUnit.GetScaleFactor())
+	dstScale := NewDecFromInt(ct.MinUnit.GetScaleFactor())
+
+	amt := coin.Amount.Mul(dstScale).Quo(srcScale)
+	return NewDecCoinFromDec(ct.MinUnit.Denom, amt), nil
+}
+
 func (ct CoinType) isMainUnit(name string) bool {
 	return ct.MainUnit.Denom == strings.TrimSpace(name)
 }
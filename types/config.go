@@ -1,8 +1,14 @@
 package types
 
 import (
+	"crypto/tls"
 	"fmt"
 	"os"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/keepalive"
 
 	"plugchain-sdk-go/types/store"
 )
@@ -16,6 +22,10 @@ const (
 	defaultFees          = "200plug"
 	defaultMode          = Sync
 	defaultGasAdjustment = 1.0
+	defaultGRPCPoolSize  = 1
+	defaultHealthCheck   = 10 * time.Second
+	defaultWSReconnect   = 20
+	defaultWSBackoff     = 30 * time.Second
 )
 
 type ClientConfig struct {
@@ -25,6 +35,15 @@ type ClientConfig struct {
 	//plugChain grpc address
 	GRPCAddr string
 
+	//additional rpc addresses of the same chain, used for load balancing and failover
+	NodeURLs []string
+
+	//additional grpc addresses of the same chain, used for load balancing and failover
+	GRPCAddrs []string
+
+	//interval between two health checks of the rpc nodes
+	HealthCheckInterval time.Duration
+
 	//plugChain chain-id
 	ChainId string
 
@@ -34,6 +53,9 @@ type ClientConfig struct {
 	//Fee
 	Fee DecCoins
 
+	//gas prices used to compute the fee as gas * price, replaces Fee if set
+	GasPrices DecCoins
+
 	//PrivKeyArmor DAO Implements
 	KeyDAO store.KeyDAO
 
@@ -52,8 +74,41 @@ type ClientConfig struct {
 	//adjustment factor to be multiplied against the estimate returned by the tx simulation;
 	GasAdjustment float64
 
+	//simulate every transaction without an explicit gas and use the adjusted estimate as gas limit
+	GasAuto bool
+
 	//whether to enable caching
 	Cached bool
+
+	//grpc transport credentials, an insecure connection is used if it is nil
+	GRPCCredentials credentials.TransportCredentials
+
+	//grpc keepalive parameters, keepalive is disabled if it is nil
+	GRPCKeepalive *keepalive.ClientParameters
+
+	//maximum size(bytes) of a grpc message the client can send or receive
+	GRPCMaxMsgSize int
+
+	//number of long-lived grpc connections shared by the queries
+	GRPCPoolSize int
+
+	//additional grpc dial options
+	GRPCDialOptions []grpc.DialOption
+
+	//persists the signed txs until their inclusion is confirmed, disabled if it is nil
+	Outbox store.Outbox
+
+	//maximum attempts to reconnect the websocket of the subscriptions before giving up, no limit if negative
+	WSMaxReconnectAttempts int
+
+	//maximum delay between two reconnect attempts, the delay doubles from one second up to it
+	WSMaxReconnectBackoff time.Duration
+
+	//buffer and overflow policy of the subscriptions with a handler
+	SubscribeOptions SubscribeOptions
+
+	//height the grpc and abci queries are pinned to, the latest height if 0; the txs still use the latest sequences
+	QueryHeight int64
 }
 
 func NewClientConfig(url, grpcAddr, chainId string, options ...Option) (ClientConfig, error) {
@@ -74,7 +129,7 @@ func NewClientConfig(url, grpcAddr, chainId string, options ...Option) (ClientCo
 }
 
 func (cfg *ClientConfig) checkAndSetDefault() error {
-	if len(cfg.NodeURL) == 0 {
+	if len(cfg.NodeURL) == 0 && len(cfg.NodeURLs) == 0 {
 		return fmt.Errorf("nodeURI is required")
 	}
 
@@ -110,9 +165,44 @@ func (cfg *ClientConfig) checkAndSetDefault() error {
 		return err
 	}
 
+	if err := GRPCPoolSizeOption(cfg.GRPCPoolSize)(cfg); err != nil {
+		return err
+	}
+
+	if err := HealthCheckIntervalOption(cfg.HealthCheckInterval)(cfg); err != nil {
+		return err
+	}
+
+	if err := WSReconnectOption(cfg.WSMaxReconnectAttempts, cfg.WSMaxReconnectBackoff)(cfg); err != nil {
+		return err
+	}
+
 	return GasAdjustmentOption(cfg.GasAdjustment)(cfg)
 }
 
+// NodeURLList returns NodeURL followed by NodeURLs, without duplicates
+func (cfg ClientConfig) NodeURLList() []string {
+	return uniqueAddrs(append([]string{cfg.NodeURL}, cfg.NodeURLs...))
+}
+
+// GRPCAddrList returns GRPCAddr followed by GRPCAddrs, without duplicates
+func (cfg ClientConfig) GRPCAddrList() []string {
+	return uniqueAddrs(append([]string{cfg.GRPCAddr}, cfg.GRPCAddrs...))
+}
+
+func uniqueAddrs(addrs []string) []string {
+	seen := make(map[string]bool, len(addrs))
+	var dst []string
+	for _, addr := range addrs {
+		if len(addr) == 0 || seen[addr] {
+			continue
+		}
+		seen[addr] = true
+		dst = append(dst, addr)
+	}
+	return dst
+}
+
 func AlgoOption(algo string) Option {
 	return func(cfg *ClientConfig) error {
 		if algo == "" {
@@ -144,6 +234,16 @@ func FeeOption(fee DecCoins) Option {
 	}
 }
 
+func GasPricesOption(gasPrices DecCoins) Option {
+	return func(cfg *ClientConfig) error {
+		if !gasPrices.IsValid() {
+			return fmt.Errorf("invalid gas prices: %s", gasPrices)
+		}
+		cfg.GasPrices = gasPrices
+		return nil
+	}
+}
+
 func GasAdjustmentOption(gasAdjustment float64) Option {
 	return func(cfg *ClientConfig) error {
 		if gasAdjustment <= 0 {
@@ -156,6 +256,13 @@ func GasAdjustmentOption(gasAdjustment float64) Option {
 
 type Option func(cfg *ClientConfig) error
 
+func GasAutoOption(enabled bool) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.GasAuto = enabled
+		return nil
+	}
+}
+
 func GasOption(gas uint64) Option {
 	return func(cfg *ClientConfig) error {
 		if gas <= 0 {
@@ -207,3 +314,125 @@ func CachedOption(enabled bool) Option {
 		return nil
 	}
 }
+
+func GRPCTLSOption(tlsCfg *tls.Config) Option {
+	return func(cfg *ClientConfig) error {
+		if tlsCfg == nil {
+			return fmt.Errorf("tls config is required")
+		}
+		cfg.GRPCCredentials = credentials.NewTLS(tlsCfg)
+		return nil
+	}
+}
+
+func GRPCCredentialsOption(creds credentials.TransportCredentials) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.GRPCCredentials = creds
+		return nil
+	}
+}
+
+func GRPCKeepaliveOption(params keepalive.ClientParameters) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.GRPCKeepalive = &params
+		return nil
+	}
+}
+
+func GRPCMaxMsgSizeOption(size int) Option {
+	return func(cfg *ClientConfig) error {
+		if size < 0 {
+			return fmt.Errorf("invalid grpc max message size: %d", size)
+		}
+		cfg.GRPCMaxMsgSize = size
+		return nil
+	}
+}
+
+func GRPCPoolSizeOption(size int) Option {
+	return func(cfg *ClientConfig) error {
+		if size <= 0 {
+			size = defaultGRPCPoolSize
+		}
+		cfg.GRPCPoolSize = size
+		return nil
+	}
+}
+
+func GRPCDialOption(opts ...grpc.DialOption) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.GRPCDialOptions = append(cfg.GRPCDialOptions, opts...)
+		return nil
+	}
+}
+
+func NodeURLsOption(urls ...string) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.NodeURLs = append(cfg.NodeURLs, urls...)
+		return nil
+	}
+}
+
+func GRPCAddrsOption(addrs ...string) Option {
+	return func(cfg *ClientConfig) error {
+		cfg.GRPCAddrs = append(cfg.GRPCAddrs, addrs...)
+		return nil
+	}
+}
+
+func OutboxOption(outbox store.Outbox) Option {
+	return func(cfg *ClientConfig) error {
+		if outbox == nil {
+			return fmt.Errorf("outbox is required")
+		}
+		cfg.Outbox = outbox
+		return nil
+	}
+}
+
+func HealthCheckIntervalOption(interval time.Duration) Option {
+	return func(cfg *ClientConfig) error {
+		if interval <= 0 {
+			interval = defaultHealthCheck
+		}
+		cfg.HealthCheckInterval = interval
+		return nil
+	}
+}
+
+func WSReconnectOption(maxAttempts int, maxBackoff time.Duration) Option {
+	return func(cfg *ClientConfig) error {
+		if maxAttempts == 0 {
+			maxAttempts = defaultWSReconnect
+		}
+		if maxBackoff <= 0 {
+			maxBackoff = defaultWSBackoff
+		}
+		cfg.WSMaxReconnectAttempts = maxAttempts
+		cfg.WSMaxReconnectBackoff = maxBackoff
+		return nil
+	}
+}
+
+func SubscribeOption(bufferSize int, overflow OverflowPolicy) Option {
+	return func(cfg *ClientConfig) error {
+		if bufferSize < 0 {
+			return fmt.Errorf("invalid subscription buffer size: %d", bufferSize)
+		}
+		cfg.SubscribeOptions = SubscribeOptions{
+			BufferSize: bufferSize,
+			Overflow:   overflow,
+		}
+		return nil
+	}
+}
+
+func QueryHeightOption(height int64) Option {
+	return func(cfg *ClientConfig) error {
+		if height < 0 {
+			return fmt.Errorf("invalid query height: %d", height)
+		}
+		cfg.QueryHeight = height
+		return nil
+	}
+}
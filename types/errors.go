@@ -2,6 +2,8 @@ package types
 
 import (
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 )
@@ -9,6 +11,8 @@ import (
 const (
 	// RootCodespace is the codespace for all errors defined in plugchain
 	RootCodespace = "sdk"
+	// ClientCodespace is the codespace of the errors raised by the client itself, which the chain never returns
+	ClientCodespace = "client"
 
 	OK                Code = 0
 	Internal          Code = 1
@@ -32,11 +36,30 @@ const (
 	TxInMempoolCache  Code = 19
 	MempoolIsFull     Code = 20
 	TxTooLarge        Code = 21
+	TxTimeoutHeight   Code = 30
+	WrongSequence     Code = 32
+	NotFound          Code = 38
+
+	// TxTimeout is in ClientCodespace
+	TxTimeout Code = 1
 )
 
 var (
 	// errUnknown = register(RootCodespace, 111222, "unknown error")
 	errInvalid = register(RootCodespace, 999999, "sdk check error")
+
+	// Sentinel errors of the common failures of a tx, to be matched with errors.Is.
+	// The errors returned by the node also match them when their codespace and code
+	// are mapped by RegisterABCIError, e.g. the errors of the module codespaces.
+	ErrInsufficientFunds = register(RootCodespace, InsufficientFunds, "insufficient funds")
+	ErrInsufficientFee   = register(RootCodespace, InsufficientFee, "insufficient fee")
+	ErrOutOfGas          = register(RootCodespace, OutOfGas, "out of gas")
+	ErrWrongSequence     = register(RootCodespace, WrongSequence, "incorrect account sequence")
+	ErrUnauthorized      = register(RootCodespace, Unauthorized, "unauthorized")
+	ErrTxInMempoolCache  = register(RootCodespace, TxInMempoolCache, "tx already in mempool")
+	ErrTxTooLarge        = register(RootCodespace, TxTooLarge, "tx too large")
+	ErrTxTimeoutHeight   = register(RootCodespace, TxTimeoutHeight, "tx timeout height")
+	ErrNotFound          = register(RootCodespace, NotFound, "not found")
 )
 
 func init() {
@@ -44,24 +67,21 @@ func init() {
 	_ = register(RootCodespace, Internal, "internal")
 	_ = register(RootCodespace, TxDecode, "tx parse error")
 	_ = register(RootCodespace, InvalidSequence, "invalid sequence")
-	_ = register(RootCodespace, Unauthorized, "unauthorized")
-	_ = register(RootCodespace, InsufficientFunds, "insufficient funds")
 	_ = register(RootCodespace, UnknownRequest, "unknown request")
 	_ = register(RootCodespace, InvalidAddress, "invalid address")
 	_ = register(RootCodespace, InvalidPubkey, "invalid pubkey")
 	_ = register(RootCodespace, UnknownAddress, "unknown address")
 	_ = register(RootCodespace, InvalidCoins, "invalid coins")
-	_ = register(RootCodespace, OutOfGas, "out of gas")
 	_ = register(RootCodespace, MemoTooLarge, "memo too large")
-	_ = register(RootCodespace, InsufficientFee, "insufficient fee")
 	_ = register(RootCodespace, TooManySignatures, "maximum number of signatures exceeded")
 	_ = register(RootCodespace, NoSignatures, "no signatures supplied")
 	_ = register(RootCodespace, ErrJsonMarshal, "failed to marshal JSON bytes")
 	_ = register(RootCodespace, ErrJsonUnmarshal, "failed to unmarshal JSON bytes")
 	_ = register(RootCodespace, InvalidRequest, "invalid request")
-	_ = register(RootCodespace, TxInMempoolCache, "tx already in mempool")
 	_ = register(RootCodespace, MempoolIsFull, "mempool is full")
-	_ = register(RootCodespace, TxTooLarge, "tx too large")
+	_ = register(ClientCodespace, TxTimeout, "tx timeout")
+
+	RegisterABCIError(RootCodespace, uint32(InvalidSequence), ErrWrongSequence)
 }
 
 type Code uint32
@@ -82,23 +102,62 @@ type Error interface {
 	Codespace() string
 }
 
-// GetError is used to covert plugchain error to sdk error
+// GetError is used to covert plugchain error to sdk error, the codespace and the code
+// returned by the node are kept
 func GetError(codespace string, code uint32, log ...string) Error {
-	codeV1, ok := v17CodeMap[code]
+	return ABCIError{
+		codespace: codespace,
+		code:      code,
+		Log:       strings.Join(log, ", "),
+	}
+}
+
+// ABCIError is an error returned by the node for a tx or a query, Log is the raw ABCI log.
+// It matches the sentinel error registered for its codespace and code with errors.Is.
+type ABCIError struct {
+	codespace string
+	code      uint32
+	Log       string
+}
+
+func (e ABCIError) Error() string {
+	return e.Log
+}
+
+func (e ABCIError) Code() uint32 {
+	return e.code
+}
+
+func (e ABCIError) Codespace() string {
+	return e.codespace
+}
+
+func (e ABCIError) Is(target error) bool {
+	t, ok := target.(sdkError)
 	if !ok {
-		codeV1 = InvalidRequest
+		return false
 	}
-	return sdkError{
-		codespace: codespace,
-		code:      uint32(codeV1),
-		desc:      log[0],
+	if t.codespace == e.codespace && t.code == e.code {
+		return true
 	}
+	sentinel, ok := abciErrors[errorID(e.codespace, e.code)]
+	return ok && sentinel.Codespace() == t.codespace && sentinel.Code() == t.code
+}
+
+// abciErrors maps the codespace and the code of the errors returned by the node to the sentinel errors
+var abciErrors = map[string]Error{}
+
+// RegisterABCIError maps an error of the node to a sentinel error, so that errors.Is(err, sentinel)
+// reports whether the node failed with it. Modules register the errors of their codespace in init.
+func RegisterABCIError(codespace string, code uint32, sentinel Error) {
+	abciErrors[errorID(codespace, code)] = sentinel
 }
 
 // Wrap extends given error with an additional information.
 //
 // If the wrapped error does not provide ABCICode method (ie. stdlib errors),
-// it will be labeled as internal error.
+// it will be labeled as internal error. An Error is returned as it is, and the
+// wrapped error is kept for errors.Is and errors.As.
 //
 // If err is nil, this returns nil, avoiding the need for an if statement when
 // wrapping a error returned at the end of a function
@@ -107,10 +166,15 @@ func Wrap(err error) Error {
 		return nil
 	}
 
+	if e, ok := err.(Error); ok {
+		return e
+	}
+
 	return sdkError{
 		codespace: errInvalid.Codespace(),
 		code:      errInvalid.Code(),
 		desc:      err.Error(),
+		cause:     err,
 	}
 }
 
@@ -119,6 +183,17 @@ func WrapWithMessage(err error, format string, args ...interface{}) Error {
 	return Wrap(errors.WithMessage(err, desc))
 }
 
+// WrapErrorf extends err with the formatted description, the codespace and the code of err
+// are kept and the result matches err with errors.Is
+func WrapErrorf(err Error, format string, args ...interface{}) Error {
+	return sdkError{
+		codespace: err.Codespace(),
+		code:      err.Code(),
+		desc:      fmt.Sprintf(format, args...) + ": " + err.Error(),
+		cause:     err,
+	}
+}
+
 // Wrapf extends given error with an additional information.
 //
 // This function works like Wrap function with additional functionality of
@@ -128,10 +203,57 @@ func Wrapf(format string, args ...interface{}) Error {
 	return Wrap(errors.New(desc))
 }
 
+// TxTimeoutError is returned when a tx is not included in a block before the timeout
+type TxTimeoutError struct {
+	Hash    string
+	Timeout time.Duration
+}
+
+func (e TxTimeoutError) Error() string {
+	return fmt.Sprintf("tx %s was not included in a block within %s", e.Hash, e.Timeout)
+}
+
+func (e TxTimeoutError) Code() uint32 {
+	return uint32(TxTimeout)
+}
+
+func (e TxTimeoutError) Codespace() string {
+	return ClientCodespace
+}
+
+// TxTooLargeError is returned when a tx exceeds the max size of the txs of a block or the max gas of a block
+type TxTooLargeError struct {
+	Size     int64
+	MaxBytes int64
+	Gas      uint64
+	MaxGas   int64
+}
+
+func (e TxTooLargeError) Error() string {
+	if e.Size > e.MaxBytes {
+		return fmt.Sprintf("tx size %d exceeds the max size %d", e.Size, e.MaxBytes)
+	}
+	return fmt.Sprintf("tx gas %d exceeds the max gas %d of a block", e.Gas, e.MaxGas)
+}
+
+func (e TxTooLargeError) Code() uint32 {
+	return uint32(TxTooLarge)
+}
+
+func (e TxTooLargeError) Codespace() string {
+	return RootCodespace
+}
+
+func (e TxTooLargeError) Is(target error) bool {
+	t, ok := target.(sdkError)
+	return ok && t.codespace == RootCodespace && t.code == uint32(TxTooLarge)
+}
+
 type sdkError struct {
 	codespace string
 	code      uint32
 	desc      string
+	cause     error
 }
 
 func (e sdkError) Error() string {
@@ -146,6 +268,10 @@ func (e sdkError) Codespace() string {
 	return e.codespace
 }
 
+func (e sdkError) Unwrap() error {
+	return e.cause
+}
+
 // register returns an error instance that should be used as the base for
 // creating error instances during runtime.
 //
@@ -177,33 +303,6 @@ func setUsed(err Error) {
 	usedCodes[errorID(err.Codespace(), err.Code())] = err
 }
 
-var v17CodeMap = map[uint32]Code{
-	0:  OK,
-	1:  Internal,
-	2:  TxDecode,
-	3:  InvalidSequence,
-	4:  Unauthorized,
-	5:  InsufficientFunds,
-	6:  UnknownRequest,
-	7:  InvalidAddress,
-	8:  InvalidPubkey,
-	9:  UnknownAddress,
-	10: InvalidCoins,
-	11: InvalidCoins,
-	12: OutOfGas,
-	13: MemoTooLarge,
-	14: InsufficientFee,
-	15: UnknownRequest,
-	16: TooManySignatures,
-	17: OutOfGas,
-	18: InvalidRequest,
-	19: InvalidRequest,
-	20: InvalidRequest,
-	21: TxTooLarge,
-	22: InvalidRequest,
-	23: InvalidRequest,
-}
-
 func CatchPanic(fn func(errMsg string)) {
 	if err := recover(); err != nil {
 		var msg string
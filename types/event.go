@@ -4,8 +4,15 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"reflect"
+	"strconv"
 	"strings"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
 )
 
 type WSClient interface {
@@ -14,14 +21,58 @@ type WSClient interface {
 	SubscribeNewBlockHeader(handler EventNewBlockHeaderHandler) (Subscription, Error)
 	SubscribeValidatorSetUpdates(handler EventValidatorSetUpdatesHandler) (Subscription, Error)
 	Unsubscribe(subscription Subscription) Error
+
+	// SubscribeChan returns the events of the query in order on a channel, closed when the subscription
+	// ends, e.g. when ctx is done
+	SubscribeChan(ctx context.Context, query string, opts SubscribeOptions) (EventSubscription, Error)
+	// SubscribeTxChan is SubscribeChan for the txs matching the builder
+	SubscribeTxChan(ctx context.Context, builder *EventQueryBuilder, opts SubscribeOptions) (TxSubscription, Error)
+	// SubscribeNewBlockChan is SubscribeChan for the blocks matching the builder
+	SubscribeNewBlockChan(ctx context.Context, builder *EventQueryBuilder, opts SubscribeOptions) (BlockSubscription, Error)
+	UnsubscribeContext(ctx context.Context, subscription Subscription) Error
+
+	// OnWSStatusChange registers a callback invoked whenever the websocket of the subscriptions
+	// to a node is lost, restored or abandoned
+	OnWSStatusChange(handler WSStatusHandler)
 }
 
+// WSStatus is the state of the websocket carrying the subscriptions to a node
+type WSStatus int
+
+const (
+	// WSConnected means the websocket is connected and every subscription is active again
+	// after a reconnect, the events emitted while reconnecting are lost
+	WSConnected WSStatus = iota
+	// WSReconnecting means the websocket was lost, no event is received until WSConnected
+	WSReconnecting
+	// WSGaveUp means the reconnect attempts are exhausted and the subscriptions to the node are removed,
+	// they must be subscribed again
+	WSGaveUp
+)
+
+func (s WSStatus) String() string {
+	switch s {
+	case WSConnected:
+		return "connected"
+	case WSReconnecting:
+		return "reconnecting"
+	case WSGaveUp:
+		return "gave up"
+	default:
+		return fmt.Sprintf("WSStatus(%d)", int(s))
+	}
+}
+
+// WSStatusHandler is called with the node, the new status and the error which caused it, if any
+type WSStatusHandler func(node string, status WSStatus, err error)
+
 type TmClient interface {
 	ABCIClient
 	SignClient
 	WSClient
 	StatusClient
 	NetworkClient
+	HistoryClient
 }
 
 type EventKey string
@@ -31,6 +82,50 @@ type Subscription struct {
 	Ctx   context.Context `json:"-"`
 	Query string          `json:"query"`
 	ID    string          `json:"id"`
+	// Errors receives the panics of the handler and the error ending the subscription, if any,
+	// it is closed with the subscription
+	Errors <-chan error `json:"-"`
+}
+
+// OverflowPolicy is what a subscription does with an event when its buffer is full
+type OverflowPolicy int
+
+const (
+	// OverflowBlock waits for the consumer. The events of the other subscriptions to the node wait too,
+	// and a node whose events are not read fast enough cancels the subscriptions, they are restored by reconnecting.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest drops the oldest buffered event
+	OverflowDropOldest
+	// OverflowError ends the subscription with ErrSubscriptionOverflow
+	OverflowError
+)
+
+// ErrSubscriptionOverflow ends a subscription with the OverflowError policy whose buffer is full
+var ErrSubscriptionOverflow = errors.New("subscription buffer is full")
+
+type SubscribeOptions struct {
+	// BufferSize is the number of events buffered for a slow consumer, 100 if it is not positive
+	BufferSize int
+	// Overflow applies when the buffer is full
+	Overflow OverflowPolicy
+}
+
+// EventSubscription delivers the events of a subscription in order, Events is closed when it ends
+type EventSubscription struct {
+	Subscription
+	Events <-chan EventData
+}
+
+// TxSubscription delivers the txs of a subscription in order, Txs is closed when it ends
+type TxSubscription struct {
+	Subscription
+	Txs <-chan EventDataTx
+}
+
+// BlockSubscription delivers the blocks of a subscription in order, Blocks is closed when it ends
+type BlockSubscription struct {
+	Subscription
+	Blocks <-chan EventDataNewBlock
 }
 
 type EventHandler func(data EventData)
@@ -54,10 +149,13 @@ func (tx EventDataTx) MarshalJson() []byte {
 
 type TxResult struct {
 	Code      uint32       `json:"code"`
+	Codespace string       `json:"codespace"`
 	Log       string       `json:"log"`
 	GasWanted int64        `json:"gas_wanted"`
 	GasUsed   int64        `json:"gas_used"`
 	Events    StringEvents `json:"events"`
+	// ABCIEvents are the events as emitted, Events merges those of the same type
+	ABCIEvents []abci.Event `json:"-"`
 }
 
 type Attributes []Attribute
@@ -141,6 +239,11 @@ type condition struct {
 	op    string
 }
 
+// EventDate is a date compared with the DATE operand, a time.Time value is compared with the TIME operand
+type EventDate time.Time
+
+const eventDateLayout = "2006-01-02"
+
 // Cond return a condition object with a key
 func Cond(key EventKey) *condition {
 	return &condition{
@@ -175,9 +278,15 @@ func (c *condition) EQ(v EventValue) *condition {
 	return c.fill(v, "=")
 }
 
-//func (c *condition) Contains(v EventValue) *condition {
-//	return c.fill(v, "CONTAINS")
-//}
+// Contains matches the events whose value of the key contains v
+func (c *condition) Contains(v string) *condition {
+	return c.fill(v, "CONTAINS")
+}
+
+// Exists matches the events having the key, whatever its value
+func (c *condition) Exists() *condition {
+	return c.fill(nil, "EXISTS")
+}
 
 func (c *condition) fill(v EventValue, op string) *condition {
 	c.value = v
@@ -190,17 +299,68 @@ func (c *condition) String() string {
 		return ""
 	}
 
-	switch c.value.(type) {
-	case int64, uint64:
-		return fmt.Sprintf("%s%s%d", c.key, c.op, c.value)
+	switch c.op {
+	case "EXISTS":
+		return fmt.Sprintf("%s EXISTS", c.key)
+	case "CONTAINS":
+		return fmt.Sprintf("%s CONTAINS %s", c.key, formatEventValue(c.value))
 	default:
-		return fmt.Sprintf("%s%s'%s'", c.key, c.op, c.value)
+		return fmt.Sprintf("%s%s%s", c.key, c.op, formatEventValue(c.value))
+	}
+}
+
+// validate reports the conditions which can not be expressed in the query language of tendermint
+func (c *condition) validate() Error {
+	if len(c.key) == 0 || len(c.op) == 0 {
+		return Wrapf("incomplete condition %s", c.key)
+	}
+	if strings.ContainsAny(string(c.key), " \t\n\r\\()\"'=><") {
+		return Wrapf("invalid key %s", c.key)
+	}
+
+	switch v := c.value.(type) {
+	case int, int8, int16, int32, int64:
+		if reflect.ValueOf(v).Int() < 0 {
+			return Wrapf("negative number %d of %s is not supported", v, c.key)
+		}
+	case float32, float64:
+		if f := reflect.ValueOf(v).Float(); f < 0 || math.IsInf(f, 0) || math.IsNaN(f) {
+			return Wrapf("invalid number %v of %s", v, c.key)
+		}
+	}
+
+	// there is no escape sequence in the strings of the query language
+	if value := formatEventValue(c.value); strings.HasPrefix(value, "'") &&
+		strings.ContainsAny(value[1:len(value)-1], "'\"") {
+		return Wrapf("the value of %s can not contain quotes: %s", c.key, value)
+	}
+	return nil
+}
+
+// formatEventValue returns the operand of the query language of a value: a number, a time, a date or a quoted string
+func formatEventValue(v EventValue) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprintf("%d", v)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return "TIME " + v.Format(time.RFC3339)
+	case EventDate:
+		return "DATE " + time.Time(v).Format(eventDateLayout)
+	default:
+		return fmt.Sprintf("'%s'", v)
 	}
 }
 
 //EventQueryBuilder is responsible for constructing listening conditions
 type EventQueryBuilder struct {
 	conditions []string
+	err        Error
 }
 
 func NewEventQueryBuilder() *EventQueryBuilder {
@@ -214,10 +374,29 @@ func (eqb *EventQueryBuilder) AddCondition(c *condition) *EventQueryBuilder {
 	if c == nil {
 		return nil
 	}
+	if err := c.validate(); err != nil && eqb.err == nil {
+		eqb.err = err
+	}
 	eqb.conditions = append(eqb.conditions, c.String())
 	return eqb
 }
 
+// AddRange adds the conditions min <= key <= max, e.g. on TxHeightKey, a nil bound is not added
+func (eqb *EventQueryBuilder) AddRange(key EventKey, min, max EventValue) *EventQueryBuilder {
+	if min != nil {
+		eqb.AddCondition(Cond(key).GTE(min))
+	}
+	if max != nil {
+		eqb.AddCondition(Cond(key).LTE(max))
+	}
+	return eqb
+}
+
+// Err returns the first condition which can not be expressed in the query language, e.g. a value with quotes
+func (eqb *EventQueryBuilder) Err() Error {
+	return eqb.err
+}
+
 //Build is responsible for constructing the listening condition into a listening instruction identified by tendermint
 func (eqb *EventQueryBuilder) Build() string {
 	var buf bytes.Buffer
@@ -0,0 +1,171 @@
+package types
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseEventQuery returns the builder of a query of tendermint, e.g. to add conditions to a query
+// received as a string: Build returns the same conditions
+func ParseEventQuery(query string) (*EventQueryBuilder, Error) {
+	p := queryParser{s: query}
+	builder := NewEventQueryBuilder()
+	for {
+		p.skipSpaces()
+		c, err := p.condition()
+		if err != nil {
+			return nil, err
+		}
+		builder.AddCondition(c)
+		if err := builder.Err(); err != nil {
+			return nil, err
+		}
+
+		p.skipSpaces()
+		if p.eof() {
+			return builder, nil
+		}
+		if !p.keyword("AND") {
+			return nil, p.errorf("AND expected")
+		}
+	}
+}
+
+type queryParser struct {
+	s   string
+	pos int
+}
+
+func (p *queryParser) condition() (*condition, Error) {
+	start := p.pos
+	for !p.eof() && !strings.ContainsRune(" \t\n\r\\()\"'=><", rune(p.s[p.pos])) {
+		p.pos++
+	}
+	if p.pos == start {
+		return nil, p.errorf("key expected")
+	}
+	c := Cond(EventKey(p.s[start:p.pos]))
+
+	p.skipSpaces()
+	if p.keyword("EXISTS") {
+		return c.Exists(), nil
+	}
+	if p.keyword("CONTAINS") {
+		p.skipSpaces()
+		v, err := p.str()
+		if err != nil {
+			return nil, err
+		}
+		return c.Contains(v), nil
+	}
+
+	var op string
+	for _, o := range []string{"<=", ">=", "<", ">", "="} {
+		if strings.HasPrefix(p.s[p.pos:], o) {
+			op = o
+			p.pos += len(o)
+			break
+		}
+	}
+	if len(op) == 0 {
+		return nil, p.errorf("operator expected")
+	}
+
+	p.skipSpaces()
+	v, err := p.operand()
+	if err != nil {
+		return nil, err
+	}
+	return c.fill(v, op), nil
+}
+
+func (p *queryParser) operand() (EventValue, Error) {
+	switch {
+	case p.eof():
+		return nil, p.errorf("operand expected")
+	case p.s[p.pos] == '\'':
+		return p.str()
+	case p.keyword("TIME"):
+		p.skipSpaces()
+		t, err := time.Parse(time.RFC3339, p.word())
+		if err != nil {
+			return nil, p.errorf("invalid time: %s", err.Error())
+		}
+		return t, nil
+	case p.keyword("DATE"):
+		p.skipSpaces()
+		t, err := time.Parse(eventDateLayout, p.word())
+		if err != nil {
+			return nil, p.errorf("invalid date: %s", err.Error())
+		}
+		return EventDate(t), nil
+	}
+
+	word := p.word()
+	if strings.Contains(word, ".") {
+		f, err := strconv.ParseFloat(word, 64)
+		if err != nil {
+			return nil, p.errorf("invalid number %s", word)
+		}
+		return f, nil
+	}
+	i, err := strconv.ParseInt(word, 10, 64)
+	if err != nil {
+		return nil, p.errorf("invalid number %s", word)
+	}
+	return i, nil
+}
+
+func (p *queryParser) str() (string, Error) {
+	if p.eof() || p.s[p.pos] != '\'' {
+		return "", p.errorf("quoted string expected")
+	}
+	end := strings.IndexByte(p.s[p.pos+1:], '\'')
+	if end < 0 {
+		return "", p.errorf("unterminated string")
+	}
+	v := p.s[p.pos+1 : p.pos+1+end]
+	p.pos += end + 2
+	return v, nil
+}
+
+// keyword consumes the keyword if it is followed by a space or the end of the query
+func (p *queryParser) keyword(keyword string) bool {
+	rest := p.s[p.pos:]
+	if !strings.HasPrefix(rest, keyword) {
+		return false
+	}
+	if len(rest) > len(keyword) && !isQuerySpace(rest[len(keyword)]) {
+		return false
+	}
+	p.pos += len(keyword)
+	return true
+}
+
+func (p *queryParser) word() string {
+	start := p.pos
+	for !p.eof() && !isQuerySpace(p.s[p.pos]) {
+		p.pos++
+	}
+	return p.s[start:p.pos]
+}
+
+func (p *queryParser) skipSpaces() {
+	for !p.eof() && isQuerySpace(p.s[p.pos]) {
+		p.pos++
+	}
+}
+
+func (p *queryParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *queryParser) errorf(format string, args ...interface{}) Error {
+	return Wrapf("invalid query %q at %d: %s", p.s, p.pos, fmt.Sprintf(format, args...))
+}
+
+func isQuerySpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,41 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventQueryBuilder(t *testing.T) {
+	from := time.Date(2021, 6, 1, 8, 30, 0, 0, time.UTC)
+	builder := NewEventQueryBuilder().
+		AddCondition(Cond(TypeKey).EQ(TxValue)).
+		AddCondition(NewCond("message", "memo").Contains("order 42")).
+		AddCondition(NewCond("transfer", "recipient").Exists()).
+		AddCondition(Cond("block.time").GTE(from)).
+		AddCondition(Cond("block.date").LE(EventDate(from))).
+		AddCondition(Cond("swap.price").GE(1.5)).
+		AddRange(TxHeightKey, int64(10), nil)
+	require.Nil(t, builder.Err())
+
+	query := builder.Build()
+	require.Equal(t, "tm.event='Tx' AND message.memo CONTAINS 'order 42' AND transfer.recipient EXISTS AND "+
+		"block.time>=TIME 2021-06-01T08:30:00Z AND block.date<DATE 2021-06-01 AND swap.price>1.5 AND tx.height>=10", query)
+
+	parsed, err := ParseEventQuery(query)
+	require.Nil(t, err)
+	require.Equal(t, query, parsed.Build())
+
+	parsed, err = ParseEventQuery("tx.height >= 5 AND  message.sender = 'plug1'")
+	require.Nil(t, err)
+	require.Equal(t, "tx.height>=5 AND message.sender='plug1'", parsed.Build())
+
+	// the strings of the query language can not contain quotes
+	builder = NewEventQueryBuilder().AddCondition(NewCond("message", "memo").EQ("it's"))
+	require.Error(t, builder.Err())
+	for _, invalid := range []string{"", "tx.height", "tx.height >", "tx.height=5 OR tx.height=6", "message.memo='it"} {
+		_, err = ParseEventQuery(invalid)
+		require.Error(t, err, invalid)
+	}
+}
@@ -124,7 +124,9 @@ func toBytes(i interface{}) []byte {
 
 // Common event types and attribute keys
 var (
-	TypeKey EventKey = "tm.event"
+	TypeKey     EventKey = "tm.event"
+	TxHashKey   EventKey = "tx.hash"
+	TxHeightKey EventKey = "tx.height"
 
 	EventTypeMessage         = "message"
 	EventTypeCreateContext   = "create_context"
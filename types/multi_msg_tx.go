@@ -0,0 +1,82 @@
+package types
+
+import "context"
+
+// MultiMsgTx collects the msgs built by the module clients, e.g. bank.SendMsg or nft.MintNFTMsg,
+// and sends them in a single atomic tx
+type MultiMsgTx struct {
+	manager TxManager
+	msgs    []Msg
+	err     error
+}
+
+func NewMultiMsgTx(manager TxManager) *MultiMsgTx {
+	return &MultiMsgTx{manager: manager}
+}
+
+// Add appends the msg returned by a msg builder, the first error is returned by Send,
+// so that the builders can be chained:
+//
+//	tx.Add(client.Token.MintTokenMsg(owner, symbol, amount, to)).
+//		Add(client.Nft.TransferNFTMsg(owner, request))
+func (mtx *MultiMsgTx) Add(msg Msg, err error) *MultiMsgTx {
+	if mtx.err != nil {
+		return mtx
+	}
+	if err != nil {
+		mtx.err = err
+		return mtx
+	}
+	mtx.msgs = append(mtx.msgs, msg)
+	return mtx
+}
+
+// AddMsgs appends msgs built by the caller
+func (mtx *MultiMsgTx) AddMsgs(msgs ...Msg) *MultiMsgTx {
+	mtx.msgs = append(mtx.msgs, msgs...)
+	return mtx
+}
+
+// Msgs returns the msgs added so far
+func (mtx *MultiMsgTx) Msgs() []Msg {
+	return mtx.msgs
+}
+
+// Err returns the first error of the msg builders
+func (mtx *MultiMsgTx) Err() error {
+	return mtx.err
+}
+
+func (mtx *MultiMsgTx) Send(baseTx BaseTx) (ResultTx, Error) {
+	return mtx.SendContext(context.Background(), baseTx)
+}
+
+// SendContext sends all the msgs in one tx signed by baseTx.From
+func (mtx *MultiMsgTx) SendContext(ctx context.Context, baseTx BaseTx) (ResultTx, Error) {
+	if err := mtx.validate(); err != nil {
+		return ResultTx{}, err
+	}
+	return mtx.manager.BuildAndSendContext(ctx, mtx.msgs, baseTx)
+}
+
+func (mtx *MultiMsgTx) Simulate(baseTx BaseTx) (ResultSimulateTx, Error) {
+	return mtx.SimulateContext(context.Background(), baseTx)
+}
+
+// SimulateContext runs all the msgs on the node without sending them
+func (mtx *MultiMsgTx) SimulateContext(ctx context.Context, baseTx BaseTx) (ResultSimulateTx, Error) {
+	if err := mtx.validate(); err != nil {
+		return ResultSimulateTx{}, err
+	}
+	return mtx.manager.SimulateContext(ctx, mtx.msgs, baseTx)
+}
+
+func (mtx *MultiMsgTx) validate() Error {
+	if mtx.err != nil {
+		return Wrap(mtx.err)
+	}
+	if len(mtx.msgs) == 0 {
+		return Wrapf("no msgs to send")
+	}
+	return nil
+}
@@ -0,0 +1,90 @@
+package types
+
+import (
+	"context"
+
+	"plugchain-sdk-go/types/query"
+)
+
+// PageFetcher fetches the page of a list query requested by req, it returns the items of the page
+// and the pagination of the response, whose next key is empty after the last page
+type PageFetcher func(ctx context.Context, req *query.PageRequest) (items []interface{}, res *query.PageResponse, err error)
+
+// PageIterator walks the items of every page of a list query, following the next key of the pages.
+// A page is fetched once the previous one is consumed, so that leaving the loop stops the queries.
+// The modules wrap it to return their items typed, e.g.:
+//
+//	it := client.Staking.IterateValidators(ctx, "", 100)
+//	for it.Next() {
+//		validator := it.Validator()
+//	}
+//	err := it.Err()
+type PageIterator struct {
+	ctx     context.Context
+	fetch   PageFetcher
+	limit   uint64
+	key     []byte
+	items   []interface{}
+	index   int
+	total   uint64
+	started bool
+	done    bool
+	err     Error
+}
+
+// NewPageIterator returns an iterator fetching pages of limit items, the default of the node if 0
+func NewPageIterator(ctx context.Context, limit uint64, fetch PageFetcher) *PageIterator {
+	return &PageIterator{ctx: ctx, fetch: fetch, limit: limit}
+}
+
+// Next moves to the next item, false is returned after the last item or on error
+func (it *PageIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	for it.index >= len(it.items) {
+		if it.done {
+			return false
+		}
+		if err := it.ctx.Err(); err != nil {
+			it.err = Wrap(err)
+			return false
+		}
+
+		// the total is only counted by the node for the first page
+		req := &query.PageRequest{Key: it.key, Limit: it.limit, CountTotal: !it.started}
+		items, res, err := it.fetch(it.ctx, req)
+		if err != nil {
+			it.err = Wrap(err)
+			return false
+		}
+		if !it.started && res != nil {
+			it.total = res.Total
+		}
+		it.started = true
+		it.items, it.index = items, 0
+		if res == nil || len(res.NextKey) == 0 {
+			it.done = true
+		} else {
+			it.key = res.NextKey
+		}
+	}
+	return true
+}
+
+// Item returns the current item, the modules return it typed
+func (it *PageIterator) Item() interface{} {
+	return it.items[it.index]
+}
+
+// Total returns the count of all the items, known once the first page is fetched
+func (it *PageIterator) Total() uint64 {
+	return it.total
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *PageIterator) Err() Error {
+	return it.err
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"plugchain-sdk-go/types/query"
+)
+
+func TestPageIterator(t *testing.T) {
+	pages := map[string][]interface{}{
+		"":  {1, 2},
+		"b": {3, 4},
+		"c": {5},
+	}
+	next := map[string]string{"": "b", "b": "c"}
+
+	var requests []*query.PageRequest
+	fetch := func(ctx context.Context, req *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		requests = append(requests, req)
+		key := string(req.Key)
+		return pages[key], &query.PageResponse{NextKey: []byte(next[key]), Total: 5}, nil
+	}
+
+	it := NewPageIterator(context.Background(), 2, fetch)
+	var items []interface{}
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	require.NoError(t, it.Err())
+	require.Equal(t, []interface{}{1, 2, 3, 4, 5}, items)
+	require.Equal(t, uint64(5), it.Total())
+	require.Len(t, requests, 3)
+	require.True(t, requests[0].CountTotal)
+	require.Equal(t, []byte("c"), requests[2].Key)
+	require.False(t, requests[2].CountTotal)
+	require.Equal(t, uint64(2), requests[2].Limit)
+
+	// leaving the loop stops the queries
+	requests = nil
+	it = NewPageIterator(context.Background(), 2, fetch)
+	for it.Next() {
+		if it.Item() == 2 {
+			break
+		}
+	}
+	require.Len(t, requests, 1)
+
+	it = NewPageIterator(context.Background(), 2, func(ctx context.Context, req *query.PageRequest) ([]interface{}, *query.PageResponse, error) {
+		return nil, nil, Wrapf("node down")
+	})
+	require.False(t, it.Next())
+	require.Error(t, it.Err())
+}
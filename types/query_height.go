@@ -0,0 +1,54 @@
+package types
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// GRPCBlockHeightHeader is the grpc metadata pinning a query to a height, the node returns
+// the height a query ran at in the header of the same name
+const GRPCBlockHeightHeader = "x-cosmos-block-height"
+
+type (
+	queryHeightKey  struct{}
+	heightRecordKey struct{}
+)
+
+// ContextWithHeight pins the grpc and abci queries made with ctx to a past height, 0 queries the
+// latest height even if the client is pinned to a height by QueryHeightOption:
+//
+//	ctx, h := types.ContextWithHeightRecord(types.ContextWithHeight(ctx, 1000))
+//	balances, err := client.Bank.QueryBalancesContext(ctx, addr)
+//	fmt.Println(h.Height())
+func ContextWithHeight(ctx context.Context, height int64) context.Context {
+	return context.WithValue(ctx, queryHeightKey{}, height)
+}
+
+// HeightFromContext returns the height the queries made with ctx are pinned to, if any
+func HeightFromContext(ctx context.Context) (int64, bool) {
+	height, ok := ctx.Value(queryHeightKey{}).(int64)
+	return height, ok
+}
+
+// HeightRecord records the height the queries made with a context ran at
+type HeightRecord struct {
+	height int64
+}
+
+// ContextWithHeightRecord returns a context recording the height of the queries made with it
+func ContextWithHeightRecord(ctx context.Context) (context.Context, *HeightRecord) {
+	record := &HeightRecord{}
+	return context.WithValue(ctx, heightRecordKey{}, record), record
+}
+
+// Height returns the height the last query ran at, 0 before a query returns
+func (r *HeightRecord) Height() int64 {
+	return atomic.LoadInt64(&r.height)
+}
+
+// RecordQueryHeight records the height a query made with ctx ran at, it is called by the clients
+func RecordQueryHeight(ctx context.Context, height int64) {
+	if record, ok := ctx.Value(heightRecordKey{}).(*HeightRecord); ok && height > 0 {
+		atomic.StoreInt64(&record.height, height)
+	}
+}
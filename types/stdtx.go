@@ -5,7 +5,11 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/gogo/protobuf/proto"
+	abci "github.com/tendermint/tendermint/abci/types"
+
 	"plugchain-sdk-go/codec"
+	"plugchain-sdk-go/types/tx/signing"
 )
 
 const (
@@ -20,6 +24,9 @@ const (
 
 type BroadcastMode string
 
+// legacyCdc encodes the sign docs of SIGN_MODE_LEGACY_AMINO_JSON
+var legacyCdc = codec.NewLegacyAmino()
+
 type Msgs []Msg
 
 func (m Msgs) Len() int {
@@ -47,16 +54,14 @@ func NewStdFee(gas uint64, amount ...Coin) StdFee {
 
 // Fee bytes for signing later
 func (fee StdFee) Bytes() []byte {
-	//if len(fee.Amount) == 0 {
-	//	fee.Amount = Coins{}
-	//}
-	//bz, err := NewCodec().MarshalJSON(fee)
-	//if err != nil {
-	//	panic(err)
-	//}
-	//return bz
-	//TODO
-	return nil
+	if len(fee.Amount) == 0 {
+		fee.Amount = Coins{}
+	}
+	bz, err := legacyCdc.MarshalJSON(fee)
+	if err != nil {
+		panic(err)
+	}
+	return bz
 }
 
 // Standard Signature
@@ -79,17 +84,23 @@ type StdSignMsg struct {
 
 // get message bytes
 func (msg StdSignMsg) Bytes(cdc codec.Marshaler) []byte {
-	var msgsBytes []json.RawMessage
-	for _, msg := range msg.Msgs {
+	return StdSignBytes(msg.ChainID, msg.AccountNumber, msg.Sequence, 0, msg.Fee, msg.Msgs, msg.Memo)
+}
+
+// StdSignBytes returns the bytes to sign for a transaction in SIGN_MODE_LEGACY_AMINO_JSON
+func StdSignBytes(chainID string, accnum, sequence, timeout uint64, fee StdFee, msgs []Msg, memo string) []byte {
+	msgsBytes := make([]json.RawMessage, 0, len(msgs))
+	for _, msg := range msgs {
 		msgsBytes = append(msgsBytes, json.RawMessage(msg.GetSignBytes()))
 	}
-	bz, err := json.Marshal(StdSignDoc{
-		AccountNumber: msg.AccountNumber,
-		ChainID:       msg.ChainID,
-		Fee:           json.RawMessage(msg.Fee.Bytes()),
-		Memo:          msg.Memo,
+	bz, err := legacyCdc.MarshalJSON(StdSignDoc{
+		AccountNumber: accnum,
+		ChainID:       chainID,
+		Fee:           json.RawMessage(fee.Bytes()),
+		Memo:          memo,
 		Msgs:          msgsBytes,
-		Sequence:      msg.Sequence,
+		Sequence:      sequence,
+		TimeoutHeight: timeout,
 	})
 	if err != nil {
 		panic(err)
@@ -109,6 +120,7 @@ type StdSignDoc struct {
 	Memo          string            `json:"memo"`
 	Msgs          []json.RawMessage `json:"msgs"`
 	Sequence      uint64            `json:"sequence"`
+	TimeoutHeight uint64            `json:"timeout_height,omitempty"`
 }
 
 // StdTx is a standard way to wrap a Msg with Fee and Signatures.
@@ -199,15 +211,32 @@ func (tx StdTx) GetMemo() string { return tx.Memo }
 func (tx StdTx) GetSignatures() []StdSignature { return tx.Signatures }
 
 type BaseTx struct {
-	From          string        `json:"from"`
-	Password      string        `json:"password"`
-	Gas           uint64        `json:"gas"`
-	Fee           DecCoins      `json:"fee"`
-	Memo          string        `json:"memo"`
-	Mode          BroadcastMode `json:"broadcast_mode"`
-	Simulate      bool          `json:"simulate"`
-	AccountNumber uint64        `json:"account_number"`
-	Sequence      uint64        `json:"sequence"`
+	From             string           `json:"from"`
+	Password         string           `json:"password"`
+	Gas              uint64           `json:"gas"`
+	Fee              DecCoins         `json:"fee"`
+	GasPrices        DecCoins         `json:"gas_prices"`
+	Memo             string           `json:"memo"`
+	Mode             BroadcastMode    `json:"broadcast_mode"`
+	Simulate         bool             `json:"simulate"`
+	GasAuto          bool             `json:"gas_auto"`
+	AccountNumber    uint64           `json:"account_number"`
+	Sequence         uint64           `json:"sequence"`
+	SignMode         signing.SignMode `json:"sign_mode"`
+	FeePayer         string           `json:"fee_payer"`
+	FeePayerPassword string           `json:"fee_payer_password"`
+	FeeGranter       string           `json:"fee_granter"`
+	TimeoutHeight    uint64           `json:"timeout_height"`
+}
+
+// UnsignedTx is a portable tx built by BuildUnsignedTx, it carries everything needed
+// to sign the tx offline, the tx itself is encoded in the protobuf JSON format
+type UnsignedTx struct {
+	ChainID       string           `json:"chain_id"`
+	AccountNumber uint64           `json:"account_number"`
+	Sequence      uint64           `json:"sequence"`
+	SignMode      signing.SignMode `json:"sign_mode,omitempty"`
+	Tx            json.RawMessage  `json:"tx"`
 }
 
 // ResultTx encapsulates the return result of the transaction. When the transaction fails,
@@ -218,6 +247,29 @@ type ResultTx struct {
 	Events    StringEvents `json:"events"`
 	Hash      string       `json:"hash"`
 	Height    int64        `json:"height"`
+	Code      uint32       `json:"code"`
+	Codespace string       `json:"codespace"`
+	Log       string       `json:"log"`
+	// ABCIEvents are the events as emitted, Events merges those of the same type
+	ABCIEvents []abci.Event `json:"-"`
+}
+
+// ResultSimulateTx is the result of a simulated tx, what the tx would do if it was sent.
+// GasEstimate is the gas used adjusted by ClientConfig.GasAdjustment.
+type ResultSimulateTx struct {
+	GasInfo      GasInfo         `json:"gas_info"`
+	GasEstimate  uint64          `json:"gas_estimate"`
+	Logs         ABCIMessageLogs `json:"logs"`
+	Events       StringEvents    `json:"events"`
+	MsgResponses []MsgResponse   `json:"msg_responses"`
+}
+
+// MsgResponse is the response of a msg of a simulated tx, Response is the decoded Data
+// and it is nil if the response type of the msg is not registered
+type MsgResponse struct {
+	MsgType  string        `json:"msg_type"`
+	Data     []byte        `json:"data"`
+	Response proto.Message `json:"response,omitempty"`
 }
 
 // ResultQueryTx is used to prepare info to display
@@ -1,11 +1,132 @@
 package store
 
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+const (
+	// aesVersion1 derives the key with scrypt and seals the data with AES-256-GCM
+	aesVersion1 byte = 1
+
+	saltLen   = 16
+	keyLen    = 32
+	headerLen = 4 // version, logN, r, p
+)
+
+var (
+	// ErrWrongPassword is returned when the data can not be authenticated with the given password
+	ErrWrongPassword = errors.New("wrong password")
+	// ErrLegacyFormat is returned when the data was not produced by a versioned AES scheme,
+	// such records need to be migrated, see Migrator
+	ErrLegacyFormat = errors.New("unversioned keystore data, migration required")
+)
+
+// ScryptLogN is the scrypt cost parameter (N = 2^ScryptLogN) used for newly encrypted data.
+// The parameters are stored with every ciphertext, so changing it does not break existing records.
+var ScryptLogN byte = 15
+
+const (
+	scryptR = 8
+	scryptP = 1
+
+	// maxScryptLogN bounds the memory scrypt allocates for a stored header, about 128·r·2^logN bytes
+	maxScryptLogN = 20
+)
+
+// AES is the default Crypto implementation. The password is stretched with scrypt using a
+// random salt, and the data is sealed with AES-256-GCM using a random nonce.
+// The output is base64(version || logN || r || p || salt || nonce || ciphertext).
 type AES struct{}
 
-func (AES) Encrypt(text string, key string) (string, error) {
-	return "", nil
+func (AES) Encrypt(text string, password string) (string, error) {
+	salt := make([]byte, saltLen)
+	if _, err := io.ReadFull(rand.Reader, salt); err != nil {
+		return "", err
+	}
+
+	header := []byte{aesVersion1, ScryptLogN, scryptR, scryptP}
+	gcm, err := newGCM(password, salt, header)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+
+	bz := make([]byte, 0, headerLen+saltLen+len(nonce)+len(text)+gcm.Overhead())
+	bz = append(bz, header...)
+	bz = append(bz, salt...)
+	bz = append(bz, nonce...)
+	// the header is bound to the ciphertext as additional data
+	bz = gcm.Seal(bz, nonce, []byte(text), header)
+	return base64.StdEncoding.EncodeToString(bz), nil
 }
 
-func (AES) Decrypt(cryptoText string, key string) (string, error) {
-	return "", nil
+func (AES) Decrypt(cryptoText string, password string) (string, error) {
+	bz, err := base64.StdEncoding.DecodeString(cryptoText)
+	if err != nil || len(bz) < headerLen+saltLen {
+		return "", ErrLegacyFormat
+	}
+
+	header := bz[:headerLen]
+	if header[0] != aesVersion1 {
+		return "", fmt.Errorf("unsupported keystore version: %d", header[0])
+	}
+
+	salt := bz[headerLen : headerLen+saltLen]
+	gcm, err := newGCM(password, salt, header)
+	if err != nil {
+		return "", err
+	}
+
+	body := bz[headerLen+saltLen:]
+	if len(body) < gcm.NonceSize()+gcm.Overhead() {
+		return "", fmt.Errorf("keystore data is truncated")
+	}
+
+	nonce, sealed := body[:gcm.NonceSize()], body[gcm.NonceSize():]
+	text, err := gcm.Open(nil, nonce, sealed, header)
+	if err != nil {
+		return "", ErrWrongPassword
+	}
+	return string(text), nil
+}
+
+// IsEncrypted returns whether data was produced by a supported version of AES.Encrypt
+func (AES) IsEncrypted(data string) bool {
+	bz, err := base64.StdEncoding.DecodeString(data)
+	if err != nil || len(bz) < headerLen+saltLen {
+		return false
+	}
+	return bz[0] == aesVersion1
+}
+
+func newGCM(password string, salt, header []byte) (cipher.AEAD, error) {
+	// the header is only authenticated after the key is derived, the parameters
+	// differing from the ones written are rejected before
+	logN, r, p := header[1], int(header[2]), int(header[3])
+	if logN == 0 || logN > maxScryptLogN || r != scryptR || p != scryptP {
+		return nil, fmt.Errorf("invalid scrypt parameters: logN=%d, r=%d, p=%d", logN, r, p)
+	}
+
+	key, err := scrypt.Key([]byte(password), salt, 1<<logN, r, p, keyLen)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
 }
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"encoding/base64"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fastScrypt lowers the scrypt cost during a test
+func fastScrypt(t *testing.T) {
+	logN := ScryptLogN
+	ScryptLogN = 10
+	t.Cleanup(func() { ScryptLogN = logN })
+}
+
+func TestAESEncryptDecrypt(t *testing.T) {
+	fastScrypt(t)
+
+	crypto := AES{}
+	cipherText, err := crypto.Encrypt("private key", "12345678")
+	require.NoError(t, err)
+	require.True(t, crypto.IsEncrypted(cipherText))
+
+	text, err := crypto.Decrypt(cipherText, "12345678")
+	require.NoError(t, err)
+	require.Equal(t, "private key", text)
+
+	_, err = crypto.Decrypt(cipherText, "87654321")
+	require.ErrorIs(t, err, ErrWrongPassword)
+
+	_, err = crypto.Decrypt("private key", "12345678")
+	require.ErrorIs(t, err, ErrLegacyFormat)
+
+	// a tampered header must not make scrypt allocate gigabytes
+	for _, header := range [][]byte{{aesVersion1, 30, scryptR, scryptP}, {aesVersion1, 10, 255, scryptP}, {aesVersion1, 10, scryptR, 16}} {
+		bz, err := base64.StdEncoding.DecodeString(cipherText)
+		require.NoError(t, err)
+		copy(bz, header)
+		_, err = crypto.Decrypt(base64.StdEncoding.EncodeToString(bz), "12345678")
+		require.Error(t, err)
+		require.NotErrorIs(t, err, ErrWrongPassword)
+	}
+}
+
+func TestLevelDBMigrate(t *testing.T) {
+	fastScrypt(t)
+
+	dir, err := os.MkdirTemp("", "keys")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dao, err := NewLevelDB(dir, nil)
+	require.NoError(t, err)
+	levelDB := dao.(LevelDBDAO)
+
+	// simulate a record written without encryption
+	raw := LevelDBDAO{db: levelDB.db, Crypto: noopCrypto{}}
+	require.NoError(t, raw.Write("test", "12345678", KeyInfo{Name: "test", PrivKeyArmor: "private key"}))
+
+	_, err = levelDB.Read("test", "12345678")
+	require.ErrorIs(t, err, ErrLegacyFormat)
+
+	migrator, ok := dao.(Migrator)
+	require.True(t, ok)
+	require.NoError(t, migrator.Migrate("test", "12345678", nil))
+
+	info, err := levelDB.Read("test", "12345678")
+	require.NoError(t, err)
+	require.Equal(t, "private key", info.PrivKeyArmor)
+
+	_, err = levelDB.Read("test", "87654321")
+	require.ErrorIs(t, err, ErrWrongPassword)
+}
+
+type noopCrypto struct{}
+
+func (noopCrypto) Encrypt(data string, password string) (string, error) { return data, nil }
+func (noopCrypto) Decrypt(data string, password string) (string, error) { return data, nil }
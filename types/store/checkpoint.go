@@ -0,0 +1,61 @@
+package store
+
+import (
+	"encoding/binary"
+	"fmt"
+	"path/filepath"
+
+	tmdb "github.com/tendermint/tm-db"
+)
+
+const (
+	checkpointDBName = "checkpoint"
+	checkpointPrefix = "height."
+)
+
+// Checkpoint persists the last height processed by a block follower, so that it resumes after a restart
+type Checkpoint interface {
+	// Load returns the height saved under name, ok is false if there is none
+	Load(name string) (height int64, ok bool, err error)
+
+	// Save records height as the last height processed under name
+	Save(name string, height int64) error
+}
+
+type LevelDBCheckpoint struct {
+	db tmdb.DB
+}
+
+func NewLevelDBCheckpoint(rootDir string) (*LevelDBCheckpoint, error) {
+	db, err := tmdb.NewGoLevelDB(checkpointDBName, filepath.Join(rootDir, "checkpoint"))
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDBCheckpoint{db: db}, nil
+}
+
+func (c *LevelDBCheckpoint) Load(name string) (height int64, ok bool, err error) {
+	bz, err := c.db.Get(checkpointKey(name))
+	if bz == nil || err != nil {
+		return 0, false, err
+	}
+	if len(bz) != 8 {
+		return 0, false, fmt.Errorf("invalid checkpoint %s", name)
+	}
+	return int64(binary.BigEndian.Uint64(bz)), true, nil
+}
+
+func (c *LevelDBCheckpoint) Save(name string, height int64) error {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	return c.db.SetSync(checkpointKey(name), bz)
+}
+
+// Close releases the database
+func (c *LevelDBCheckpoint) Close() error {
+	return c.db.Close()
+}
+
+func checkpointKey(name string) []byte {
+	return []byte(checkpointPrefix + name)
+}
@@ -1,6 +1,7 @@
 package store
 
 import (
+	"errors"
 	"fmt"
 	"path/filepath"
 
@@ -13,6 +14,8 @@ const (
 	infoSuffix = "info"
 )
 
+var _ Migrator = LevelDBDAO{}
+
 type LevelDBDAO struct {
 	db tmdb.DB
 	Crypto
@@ -93,6 +96,50 @@ func (k LevelDBDAO) Has(name string) bool {
 	return existed
 }
 
+//Migrate re-encrypts a key record that was written with legacy crypto (nil means the record
+//is stored in plaintext) using the current Crypto of the DAO
+func (k LevelDBDAO) Migrate(name, password string, legacy Crypto) error {
+	bz, err := k.db.Get(infoKey(name))
+	if err != nil {
+		return err
+	}
+	if bz == nil {
+		return fmt.Errorf("name %s not exist", name)
+	}
+
+	var store KeyInfo
+	if err := json.Unmarshal(bz, &store); err != nil {
+		return err
+	}
+
+	if len(store.PrivKeyArmor) == 0 {
+		return fmt.Errorf("private key of %s was not stored, it must be recovered or imported again", name)
+	}
+
+	// records that are already in the current format are left untouched
+	if _, err := k.Decrypt(store.PrivKeyArmor, password); err == nil {
+		return nil
+	} else if !errors.Is(err, ErrLegacyFormat) {
+		return err
+	}
+
+	privStr := store.PrivKeyArmor
+	if legacy != nil {
+		if privStr, err = legacy.Decrypt(store.PrivKeyArmor, password); err != nil {
+			return err
+		}
+	}
+
+	if store.PrivKeyArmor, err = k.Encrypt(privStr, password); err != nil {
+		return err
+	}
+
+	if bz, err = json.Marshal(store); err != nil {
+		return err
+	}
+	return k.db.SetSync(infoKey(name), bz)
+}
+
 func infoKey(name string) []byte {
 	return []byte(fmt.Sprintf("%s.%s", name, infoSuffix))
 }
@@ -1,5 +1,7 @@
 package store
 
+import "fmt"
+
 // Use memory as storage, use with caution in build environment
 type MemoryDAO struct {
 	store map[string]KeyInfo
@@ -17,15 +19,36 @@ func NewMemory(crypto Crypto) MemoryDAO {
 }
 
 func (m MemoryDAO) Write(name, password string, store KeyInfo) error {
+	privStr, err := m.Encrypt(store.PrivKeyArmor, password)
+	if err != nil {
+		return err
+	}
+
+	store.PrivKeyArmor = privStr
 	m.store[name] = store
 	return nil
 }
 
 func (m MemoryDAO) Read(name, password string) (KeyInfo, error) {
-	return m.store[name], nil
+	store, ok := m.store[name]
+	if !ok {
+		return store, fmt.Errorf("name %s not exist", name)
+	}
+
+	if len(password) > 0 {
+		privStr, err := m.Decrypt(store.PrivKeyArmor, password)
+		if err != nil {
+			return store, err
+		}
+		store.PrivKeyArmor = privStr
+	}
+	return store, nil
 }
 
 func (m MemoryDAO) Delete(name, password string) error {
+	if _, err := m.Read(name, password); err != nil {
+		return err
+	}
 	delete(m.store, name)
 	return nil
 }
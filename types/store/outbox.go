@@ -0,0 +1,132 @@
+package store
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tendermint/tendermint/libs/json"
+	tmdb "github.com/tendermint/tm-db"
+)
+
+const (
+	outboxDBName = "outbox"
+	outboxPrefix = "tx."
+)
+
+// OutboxStatus is the state of a tx recorded in the outbox
+type OutboxStatus string
+
+const (
+	// OutboxPending is a tx broadcast, or about to be, whose inclusion is not confirmed yet
+	OutboxPending OutboxStatus = "pending"
+	// OutboxFailed is a tx that failed in a block or that can no longer be included
+	OutboxFailed OutboxStatus = "failed"
+)
+
+// OutboxEntry is a signed tx recorded before it is broadcast
+type OutboxEntry struct {
+	Hash          string       `json:"hash"`
+	Address       string       `json:"address"`
+	Sequence      uint64       `json:"sequence"`
+	TimeoutHeight uint64       `json:"timeout_height"`
+	TxBytes       []byte       `json:"tx_bytes"`
+	Status        OutboxStatus `json:"status"`
+	Log           string       `json:"log"`
+	CreatedAt     time.Time    `json:"created_at"`
+}
+
+// Outbox persists the signed txs until their inclusion is confirmed, so that they
+// can be broadcast again after a restart
+type Outbox interface {
+	// Put saves the entry, replacing the entry of the same hash
+	Put(entry OutboxEntry) error
+
+	// Get returns the entry of the tx hash, ok is false if there is none
+	Get(hash string) (entry OutboxEntry, ok bool, err error)
+
+	// Delete removes the entry of the tx hash
+	Delete(hash string) error
+
+	// List returns the entries with the status, all the entries if status is empty,
+	// ordered by address and sequence
+	List(status OutboxStatus) ([]OutboxEntry, error)
+}
+
+type LevelDBOutbox struct {
+	db tmdb.DB
+}
+
+func NewLevelDBOutbox(rootDir string) (*LevelDBOutbox, error) {
+	db, err := tmdb.NewGoLevelDB(outboxDBName, filepath.Join(rootDir, "outbox"))
+	if err != nil {
+		return nil, err
+	}
+	return &LevelDBOutbox{db: db}, nil
+}
+
+func (o *LevelDBOutbox) Put(entry OutboxEntry) error {
+	entry.Hash = strings.ToUpper(entry.Hash)
+	bz, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return o.db.SetSync(outboxKey(entry.Hash), bz)
+}
+
+func (o *LevelDBOutbox) Get(hash string) (entry OutboxEntry, ok bool, err error) {
+	bz, err := o.db.Get(outboxKey(strings.ToUpper(hash)))
+	if bz == nil || err != nil {
+		return entry, false, err
+	}
+	if err := json.Unmarshal(bz, &entry); err != nil {
+		return entry, false, err
+	}
+	return entry, true, nil
+}
+
+func (o *LevelDBOutbox) Delete(hash string) error {
+	return o.db.DeleteSync(outboxKey(strings.ToUpper(hash)))
+}
+
+func (o *LevelDBOutbox) List(status OutboxStatus) ([]OutboxEntry, error) {
+	it, err := tmdb.IteratePrefix(o.db, []byte(outboxPrefix))
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+
+	var entries []OutboxEntry
+	for ; it.Valid(); it.Next() {
+		var entry OutboxEntry
+		if err := json.Unmarshal(it.Value(), &entry); err != nil {
+			return nil, fmt.Errorf("invalid outbox entry %s: %w", it.Key(), err)
+		}
+		if len(status) == 0 || entry.Status == status {
+			entries = append(entries, entry)
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, err
+	}
+
+	// the txs of an account must be broadcast again in the order of their sequences
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Address != entries[j].Address {
+			return entries[i].Address < entries[j].Address
+		}
+		return entries[i].Sequence < entries[j].Sequence
+	})
+	return entries, nil
+}
+
+// Close releases the database
+func (o *LevelDBOutbox) Close() error {
+	return o.db.Close()
+}
+
+func outboxKey(hash string) []byte {
+	return []byte(outboxPrefix + hash)
+}
@@ -29,6 +29,13 @@ type KeyDAO interface {
 	Has(name string) bool
 }
 
+// Migrator is implemented by the KeyDAOs able to re-encrypt the records written with a legacy Crypto,
+// e.g. the KeyDAO returned by NewLevelDB
+type Migrator interface {
+	// Migrate re-encrypts the record of name written with legacy, nil if it is stored in plaintext
+	Migrate(name, password string, legacy Crypto) error
+}
+
 type Crypto interface {
 	Encrypt(data string, password string) (string, error)
 	Decrypt(data string, password string) (string, error)
@@ -19,6 +19,7 @@ type (
 	SignClient    = tmclient.SignClient
 	StatusClient  = tmclient.StatusClient
 	NetworkClient = tmclient.NetworkClient
+	HistoryClient = tmclient.HistoryClient
 	Header        = tmtypes.Header
 	Pair          = kv.Pair
 
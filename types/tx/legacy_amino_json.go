@@ -0,0 +1,46 @@
+package tx
+
+import (
+	"fmt"
+
+	sdk "plugchain-sdk-go/types"
+	signingtypes "plugchain-sdk-go/types/tx/signing"
+)
+
+// signModeLegacyAminoJSONHandler defines the SIGN_MODE_LEGACY_AMINO_JSON SignModeHandler
+type signModeLegacyAminoJSONHandler struct{}
+
+var _ sdk.SignModeHandler = signModeLegacyAminoJSONHandler{}
+
+// DefaultMode implements SignModeHandler.DefaultMode
+func (signModeLegacyAminoJSONHandler) DefaultMode() signingtypes.SignMode {
+	return signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON
+}
+
+// Modes implements SignModeHandler.Modes
+func (signModeLegacyAminoJSONHandler) Modes() []signingtypes.SignMode {
+	return []signingtypes.SignMode{signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON}
+}
+
+// GetSignBytes implements SignModeHandler.GetSignBytes
+func (signModeLegacyAminoJSONHandler) GetSignBytes(mode signingtypes.SignMode, data sdk.SignerData, tx sdk.Tx) ([]byte, error) {
+	if mode != signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON {
+		return nil, fmt.Errorf("expected %s, got %s", signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON, mode)
+	}
+
+	protoTx, ok := tx.(*wrapper)
+	if !ok {
+		return nil, fmt.Errorf("can only handle a protobuf Tx, got %T", tx)
+	}
+
+	// the extension options are not part of the amino sign doc, so they could be changed after signing
+	if len(protoTx.GetExtensionOptions()) > 0 || len(protoTx.GetNonCriticalExtensionOptions()) > 0 {
+		return nil, fmt.Errorf("%s does not support protobuf extension options", signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON)
+	}
+
+	return sdk.StdSignBytes(
+		data.ChainID, data.AccountNumber, data.Sequence, protoTx.GetTimeoutHeight(),
+		sdk.StdFee{Amount: protoTx.GetFee(), Gas: protoTx.GetGas()},
+		tx.GetMsgs(), protoTx.GetMemo(),
+	), nil
+}
@@ -10,7 +10,7 @@ import (
 // DefaultSignModes are the default sign modes enabled for protobuf transactions.
 var DefaultSignModes = []signingtypes.SignMode{
 	signingtypes.SignMode_SIGN_MODE_DIRECT,
-	//signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
+	signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON,
 }
 
 // makeSignModeHandler returns the default protobuf SignModeHandler supporting
@@ -27,7 +27,7 @@ func MakeSignModeHandler(modes []signingtypes.SignMode) types.SignModeHandler {
 		case signingtypes.SignMode_SIGN_MODE_DIRECT:
 			handlers[i] = signModeDirectHandler{}
 		case signingtypes.SignMode_SIGN_MODE_LEGACY_AMINO_JSON:
-			//handlers[i] = signModeLegacyAminoJSONHandler{}
+			handlers[i] = signModeLegacyAminoJSONHandler{}
 		default:
 			panic(fmt.Errorf("unsupported sign mode %+v", mode))
 		}
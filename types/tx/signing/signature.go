@@ -4,8 +4,12 @@ import (
 	"fmt"
 
 	"github.com/tendermint/tendermint/crypto"
+
+	codectypes "plugchain-sdk-go/codec/types"
 )
 
+var _, _ codectypes.UnpackInterfacesMessage = &SignatureDescriptors{}, &SignatureDescriptor{}
+
 type SignatureV2 struct {
 	//Verify signed public key
 	PubKey crypto.PubKey
@@ -79,3 +83,18 @@ func SignatureDataFromProto(descData *SignatureDescriptor_Data) SignatureData {
 		panic(fmt.Errorf("unexpected case %+v", descData))
 	}
 }
+
+// UnpackInterfaces implements the UnpackInterfaceMessages.UnpackInterfaces method
+func (sds *SignatureDescriptors) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	for _, sig := range sds.Signatures {
+		if err := sig.UnpackInterfaces(unpacker); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UnpackInterfaces implements the UnpackInterfaceMessages.UnpackInterfaces method
+func (sd *SignatureDescriptor) UnpackInterfaces(unpacker codectypes.AnyUnpacker) error {
+	return unpacker.UnpackAny(sd.PublicKey, new(crypto.PubKey))
+}
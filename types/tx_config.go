@@ -21,6 +21,8 @@ type (
 		SetFeeAmount(amount Coins)
 		SetGasLimit(limit uint64)
 		SetTimeoutHeight(height uint64)
+		SetFeePayer(feePayer AccAddress)
+		SetFeeGranter(feeGranter AccAddress)
 	}
 
 	//Encoders and decoders containing transactions
@@ -0,0 +1,82 @@
+package types
+
+// TxOrder is the order of the heights of the txs searched
+type TxOrder string
+
+const (
+	TxOrderAsc  TxOrder = "asc"
+	TxOrderDesc TxOrder = "desc"
+)
+
+// TxSearchRequest is a page of the txs searched
+type TxSearchRequest struct {
+	// Page is the number of the page from 1, default 1
+	Page int `json:"page"`
+	// Size is the number of txs of a page, default 30, at most 100
+	Size int `json:"size"`
+	// Order is the order of the heights, default TxOrderAsc
+	Order TxOrder `json:"order"`
+}
+
+// TxIterator walks the txs of every page of a search, the next page is fetched when a page is consumed:
+//
+//	it := client.IterateTxs(ctx, builder, types.TxOrderDesc, 100)
+//	for it.Next() {
+//		tx := it.Tx()
+//	}
+//	err := it.Err()
+type TxIterator struct {
+	search func(page int) (ResultSearchTxs, error)
+	page   int
+	txs    []ResultQueryTx
+	index  int
+	total  int
+	seen   int
+	done   bool
+	err    error
+}
+
+// NewTxIterator returns an iterator fetching the pages with search
+func NewTxIterator(search func(page int) (ResultSearchTxs, error)) *TxIterator {
+	return &TxIterator{search: search}
+}
+
+// Next moves to the next tx, false is returned after the last tx or on error
+func (it *TxIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	it.index++
+	for it.index >= len(it.txs) {
+		if it.done {
+			return false
+		}
+		it.page++
+		res, err := it.search(it.page)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.txs, it.index = res.Txs, 0
+		it.total = res.Total
+		it.seen += len(res.Txs)
+		it.done = len(res.Txs) == 0 || it.seen >= res.Total
+	}
+	return true
+}
+
+// Tx returns the current tx
+func (it *TxIterator) Tx() ResultQueryTx {
+	return it.txs[it.index]
+}
+
+// Total returns the count of all the txs matching the query, known once a page is fetched
+func (it *TxIterator) Total() int {
+	return it.total
+}
+
+// Err returns the error which stopped the iteration, if any
+func (it *TxIterator) Err() error {
+	return it.err
+}
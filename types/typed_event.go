@@ -0,0 +1,165 @@
+package types
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// TypedEvent is an event decoded into the struct registered for its type by RegisterEvent
+type TypedEvent interface {
+	EventType() string
+}
+
+// RawEvent is an event whose type is not registered, kept as it is
+type RawEvent struct {
+	StringEvent
+}
+
+func (e RawEvent) EventType() string {
+	return e.Type
+}
+
+var (
+	// eventTypes maps the event types to the structs registered by the modules
+	eventTypes = map[string]reflect.Type{}
+
+	timeType  = reflect.TypeOf(time.Time{})
+	coinsType = reflect.TypeOf(Coins{})
+	coinType  = reflect.TypeOf(Coin{})
+	intType   = reflect.TypeOf(Int{})
+	decType   = reflect.TypeOf(Dec{})
+)
+
+// RegisterEvent registers the struct of an event type, its fields are decoded from the attributes named
+// by their `event` tag: strings, bools, integers, Coins, Coin, Int, Dec and RFC3339 times are supported.
+// Modules register their events in init.
+func RegisterEvent(event TypedEvent) {
+	typ := reflect.TypeOf(event)
+	if typ.Kind() != reflect.Struct {
+		panic("event must be a struct: " + typ.String())
+	}
+	eventTypes[event.EventType()] = typ
+}
+
+// DecodeEvents returns the events as emitted by the node, e.g. the ABCIEvents of a TxResult, decoded into
+// the structs registered by the modules, the events of the types not registered are returned as RawEvent
+func DecodeEvents(events []abci.Event) ([]TypedEvent, Error) {
+	decoded := make([]TypedEvent, 0, len(events))
+	for _, e := range events {
+		event := StringifyEvent(e)
+		typ, ok := eventTypes[event.Type]
+		if !ok {
+			decoded = append(decoded, RawEvent{StringEvent: event})
+			continue
+		}
+
+		typed, err := decodeEvent(typ, event)
+		if err != nil {
+			return nil, err
+		}
+		decoded = append(decoded, typed)
+	}
+	return decoded, nil
+}
+
+// DecodeEvents decodes the events emitted by the tx, see DecodeEvents
+func (r TxResult) DecodeEvents() ([]TypedEvent, Error) {
+	return DecodeEvents(r.ABCIEvents)
+}
+
+// DecodeEvents decodes the events emitted by the tx, see DecodeEvents
+func (r ResultTx) DecodeEvents() ([]TypedEvent, Error) {
+	return DecodeEvents(r.ABCIEvents)
+}
+
+func decodeEvent(typ reflect.Type, e StringEvent) (TypedEvent, Error) {
+	event := reflect.New(typ).Elem()
+	for i := 0; i < typ.NumField(); i++ {
+		key := typ.Field(i).Tag.Get("event")
+		if len(key) == 0 {
+			continue
+		}
+		for _, attr := range e.Attributes {
+			if attr.Key != key {
+				continue
+			}
+			if err := decodeAttribute(event.Field(i), attr.Value); err != nil {
+				return nil, Wrapf("invalid attribute %s of event %s: %s", key, e.Type, err.Error())
+			}
+			break
+		}
+	}
+	return event.Interface().(TypedEvent), nil
+}
+
+func decodeAttribute(field reflect.Value, value string) error {
+	if len(value) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	var err error
+	switch field.Type() {
+	case timeType:
+		v, err = time.Parse(time.RFC3339Nano, value)
+	case coinsType:
+		v, err = ParseCoins(value)
+	case coinType:
+		v, err = ParseCoin(value)
+	case intType:
+		i, ok := NewIntFromString(value)
+		if !ok {
+			return Wrapf("invalid integer %s", value)
+		}
+		v = i
+	case decType:
+		v, err = NewDecFromStr(value)
+	default:
+		switch field.Kind() {
+		case reflect.String:
+			field.SetString(value)
+		case reflect.Bool:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return err
+			}
+			field.SetBool(b)
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			i, err := strconv.ParseInt(value, 10, field.Type().Bits())
+			if err != nil {
+				return err
+			}
+			field.SetInt(i)
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			u, err := strconv.ParseUint(value, 10, field.Type().Bits())
+			if err != nil {
+				return err
+			}
+			field.SetUint(u)
+		default:
+			return Wrapf("unsupported field type %s", field.Type())
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	field.Set(reflect.ValueOf(v))
+	return nil
+}
+
+// MessageEvent is emitted for every msg of a tx
+type MessageEvent struct {
+	Action string `event:"action"`
+	Module string `event:"module"`
+	Sender string `event:"sender"`
+}
+
+func (MessageEvent) EventType() string { return EventTypeMessage }
+
+func init() {
+	RegisterEvent(MessageEvent{})
+}
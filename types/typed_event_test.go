@@ -0,0 +1,61 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+type testTransferEvent struct {
+	Recipient string `event:"recipient"`
+	Amount    Coins  `event:"amount"`
+	Shares    Dec    `event:"shares"`
+	Index     uint64 `event:"index"`
+}
+
+func (testTransferEvent) EventType() string { return "test_transfer" }
+
+func TestDecodeEvents(t *testing.T) {
+	RegisterEvent(testTransferEvent{})
+	event := func(typ string, attrs ...string) abci.Event {
+		e := abci.Event{Type: typ}
+		for i := 0; i < len(attrs); i += 2 {
+			e.Attributes = append(e.Attributes, abci.EventAttribute{Key: []byte(attrs[i]), Value: []byte(attrs[i+1])})
+		}
+		return e
+	}
+
+	// the events of a MsgSend, each event emitted is decoded on its own
+	events := []abci.Event{
+		event("message", "action", "send"),
+		event("test_transfer", "recipient", "to1", "amount", "10uplugcn", "shares", "1.5", "index", "1"),
+		event("message", "sender", "from"),
+		event("test_transfer", "recipient", "to2", "amount", "20uplugcn"),
+		event("message", "module", "bank"),
+		event("unknown", "key", "value"),
+	}
+
+	decoded, err := DecodeEvents(events)
+	require.Nil(t, err)
+	require.Equal(t, []TypedEvent{
+		MessageEvent{Action: "send"},
+		testTransferEvent{
+			Recipient: "to1",
+			Amount:    NewCoins(NewCoin("uplugcn", NewInt(10))),
+			Shares:    NewDecWithPrec(15, 1),
+			Index:     1,
+		},
+		MessageEvent{Sender: "from"},
+		testTransferEvent{Recipient: "to2", Amount: NewCoins(NewCoin("uplugcn", NewInt(20)))},
+		MessageEvent{Module: "bank"},
+		RawEvent{StringEvent: StringEvent{Type: "unknown", Attributes: []Attribute{{Key: "key", Value: "value"}}}},
+	}, decoded)
+
+	decoded, err = TxResult{ABCIEvents: events[:1]}.DecodeEvents()
+	require.Nil(t, err)
+	require.Equal(t, []TypedEvent{MessageEvent{Action: "send"}}, decoded)
+
+	_, err = DecodeEvents([]abci.Event{event("test_transfer", "amount", "invalid")})
+	require.Error(t, err)
+}
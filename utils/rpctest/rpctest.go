@@ -0,0 +1,48 @@
+// Package rpctest provides a stub tendermint node for the tests, answering the JSON-RPC requests sent over HTTP
+package rpctest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	rpctypes "github.com/tendermint/tendermint/rpc/jsonrpc/types"
+)
+
+// NewHandler returns a handler answering the JSON-RPC requests with the handler of their method,
+// the methods without handler are answered with a method not found error
+func NewHandler(handlers map[string]func(req rpctypes.RPCRequest) rpctypes.RPCResponse) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req rpctypes.RPCRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		res := rpctypes.NewRPCErrorResponse(req.ID, -32601, "Method not found", req.Method)
+		if handle, ok := handlers[req.Method]; ok {
+			res = handle(req)
+		}
+		bz, err := json.Marshal(res)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		_, _ = w.Write(bz)
+	})
+}
+
+// NewNode starts a node serving NewHandler(handlers), it is closed when the test ends
+func NewNode(t testing.TB, handlers map[string]func(req rpctypes.RPCRequest) rpctypes.RPCResponse) *httptest.Server {
+	node := httptest.NewServer(NewHandler(handlers))
+	t.Cleanup(node.Close)
+	return node
+}
+
+// Result returns a handler answering every request with result
+func Result(result interface{}) func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+	return func(req rpctypes.RPCRequest) rpctypes.RPCResponse {
+		return rpctypes.NewRPCSuccessResponse(req.ID, result)
+	}
+}